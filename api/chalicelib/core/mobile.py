@@ -1,13 +1,22 @@
+import re
+
 from chalicelib.core import projects
 from chalicelib.utils import s3
 from decouple import config
 
+# Screenshots which SDK didn't upload because the backend already had them are referenced by content hash
+HASH_KEY = re.compile(r"^[0-9a-f]{64}$")
+
 
 def sign_keys(project_id, session_id, keys):
     result = []
     project_key = projects.get_project_key(project_id)
     for k in keys:
+        if HASH_KEY.match(k):
+            key = f"{project_id}/{k}"
+        else:
+            key = f"{project_key}/{session_id}/{k}"
         result.append(s3.get_presigned_url_for_sharing(bucket=config("iosBucket"),
-                                                       key=f"{project_key}/{session_id}/{k}",
+                                                       key=key,
                                                        expires_in=60 * 60))
     return result
@@ -654,6 +654,17 @@ def update_capture_status(projectId: int, data: schemas.SampleRateSchema = Body(
     return {"data": projects.update_capture_status(project_id=projectId, changes=data.dict())}
 
 
+@app.get('/{projectId}/secret_key', tags=["projects"])
+def get_secret_key(projectId: int, context: schemas.CurrentContext = Depends(OR_context)):
+    return projects.get_secret_key(tenant_id=context.tenant_id, user_id=context.user_id, project_id=projectId)
+
+
+@app.post('/{projectId}/secret_key/rotate', tags=["projects"])
+@app.put('/{projectId}/secret_key/rotate', tags=["projects"])
+def rotate_secret_key(projectId: int, context: schemas.CurrentContext = Depends(OR_context)):
+    return projects.rotate_secret_key(tenant_id=context.tenant_id, user_id=context.user_id, project_id=projectId)
+
+
 @app.get('/announcements', tags=["announcements"])
 def get_all_announcements(context: schemas.CurrentContext = Depends(OR_context)):
     return {"data": announcements.get_all(context.user_id)}
@@ -12,9 +12,11 @@ import (
 	"openreplay/backend/internal/assets"
 	"openreplay/backend/internal/assets/cacher"
 	config "openreplay/backend/internal/config/assets"
+	"openreplay/backend/pkg/health"
 	"openreplay/backend/pkg/messages"
 	"openreplay/backend/pkg/monitoring"
 	"openreplay/backend/pkg/queue"
+	"openreplay/backend/pkg/redisstream"
 )
 
 func main() {
@@ -24,7 +26,13 @@ func main() {
 
 	cfg := config.New()
 
+	healthCheck := health.New("assets", cfg.HealthConsumerTimeout)
+	if redisstream.GetClient() != nil {
+		healthCheck.AddCheck("redis", redisstream.Ping)
+	}
+
 	cacher := cacher.NewCacher(cfg, metrics)
+	healthCheck.AddCheck("s3", cacher.Ping)
 
 	totalAssets, err := metrics.RegisterCounter("assets_total")
 	if err != nil {
@@ -39,7 +47,7 @@ func main() {
 				if iter.Type() == messages.MsgAssetCache {
 					m := iter.Message().Decode()
 					if m == nil {
-						return
+						continue
 					}
 					msg := m.(*messages.AssetCache)
 					cacher.CacheURL(sessionID, msg.URL)
@@ -47,7 +55,7 @@ func main() {
 				} else if iter.Type() == messages.MsgErrorEvent {
 					m := iter.Message().Decode()
 					if m == nil {
-						return
+						continue
 					}
 					msg := m.(*messages.ErrorEvent)
 					if msg.Source != "js_exception" {
@@ -67,6 +75,7 @@ func main() {
 		},
 		true,
 		cfg.MessageSizeLimit,
+		metrics,
 	)
 
 	log.Printf("Cacher service started\n")
@@ -79,6 +88,7 @@ func main() {
 		select {
 		case sig := <-sigchan:
 			log.Printf("Caught signal %v: terminating\n", sig)
+			healthCheck.Drain(cfg.HealthDrainTimeout)
 			consumer.Close()
 			os.Exit(0)
 		case err := <-cacher.Errors:
@@ -90,6 +100,7 @@ func main() {
 			if err := consumer.ConsumeNext(); err != nil {
 				log.Fatalf("Error on consumption: %v", err)
 			}
+			healthCheck.Consumed()
 		}
 	}
 }
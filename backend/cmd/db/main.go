@@ -15,10 +15,12 @@ import (
 	"openreplay/backend/pkg/db/postgres"
 	"openreplay/backend/pkg/handlers"
 	custom2 "openreplay/backend/pkg/handlers/custom"
+	"openreplay/backend/pkg/health"
 	logger "openreplay/backend/pkg/log"
 	"openreplay/backend/pkg/messages"
 	"openreplay/backend/pkg/monitoring"
 	"openreplay/backend/pkg/queue"
+	"openreplay/backend/pkg/redisstream"
 	"openreplay/backend/pkg/sessions"
 )
 
@@ -29,8 +31,14 @@ func main() {
 
 	cfg := db.New()
 
+	healthCheck := health.New("db", cfg.HealthConsumerTimeout)
+	if redisstream.GetClient() != nil {
+		healthCheck.AddCheck("redis", redisstream.Ping)
+	}
+
 	// Init database
 	pg := cache.NewPGCache(postgres.NewConn(cfg.Postgres, cfg.BatchQueueLimit, cfg.BatchSizeLimit, metrics), cfg.ProjectExpirationTimeoutMs)
+	healthCheck.AddCheck("postgres", pg.Ping)
 	defer pg.Close()
 
 	// HandlersFabric returns the list of message handlers we want to be applied to each incoming message.
@@ -46,7 +54,8 @@ func main() {
 	builderMap := sessions.NewBuilderMap(handlersFabric)
 
 	keepMessage := func(tp int) bool {
-		return tp == messages.MsgMetadata || tp == messages.MsgIssueEvent || tp == messages.MsgSessionStart || tp == messages.MsgSessionEnd || tp == messages.MsgUserID || tp == messages.MsgUserAnonymousID || tp == messages.MsgCustomEvent || tp == messages.MsgClickEvent || tp == messages.MsgInputEvent || tp == messages.MsgPageEvent || tp == messages.MsgErrorEvent || tp == messages.MsgFetchEvent || tp == messages.MsgGraphQLEvent || tp == messages.MsgIntegrationEvent || tp == messages.MsgPerformanceTrackAggr || tp == messages.MsgResourceEvent || tp == messages.MsgLongTask || tp == messages.MsgJSException || tp == messages.MsgResourceTiming || tp == messages.MsgRawCustomEvent || tp == messages.MsgCustomIssue || tp == messages.MsgFetch || tp == messages.MsgGraphQL || tp == messages.MsgStateAction || tp == messages.MsgSetInputTarget || tp == messages.MsgSetInputValue || tp == messages.MsgCreateDocument || tp == messages.MsgMouseClick || tp == messages.MsgSetPageLocation || tp == messages.MsgPageLoadTiming || tp == messages.MsgPageRenderTiming
+		return tp == messages.MsgMetadata || tp == messages.MsgIssueEvent || tp == messages.MsgSessionStart || tp == messages.MsgSessionEnd || tp == messages.MsgUserID || tp == messages.MsgUserAnonymousID || tp == messages.MsgCustomEvent || tp == messages.MsgClickEvent || tp == messages.MsgInputEvent || tp == messages.MsgPageEvent || tp == messages.MsgErrorEvent || tp == messages.MsgFetchEvent || tp == messages.MsgGraphQLEvent || tp == messages.MsgIntegrationEvent || tp == messages.MsgPerformanceTrackAggr || tp == messages.MsgResourceEvent || tp == messages.MsgLongTask || tp == messages.MsgJSException || tp == messages.MsgResourceTiming || tp == messages.MsgRawCustomEvent || tp == messages.MsgCustomIssue || tp == messages.MsgFetch || tp == messages.MsgGraphQL || tp == messages.MsgStateAction || tp == messages.MsgSetInputTarget || tp == messages.MsgSetInputValue || tp == messages.MsgCreateDocument || tp == messages.MsgMouseClick || tp == messages.MsgSetPageLocation || tp == messages.MsgPageLoadTiming || tp == messages.MsgPageRenderTiming || tp == messages.MsgSessionAssociation || tp == messages.MsgSessionGeo || tp == messages.MsgSessionClockSkew ||
+			tp == messages.MsgAndroidSessionStart || tp == messages.MsgIOSSessionStart || tp == messages.MsgIOSSessionEnd
 	}
 
 	var producer types.Producer = nil
@@ -58,6 +67,7 @@ func main() {
 	// Init modules
 	saver := datasaver.New(pg, producer)
 	saver.InitStats()
+	saver.AddHealthChecks(healthCheck)
 	statsLogger := logger.NewQueueStats(cfg.LoggerTimeout)
 
 	// Handler logic
@@ -68,9 +78,10 @@ func main() {
 			if !keepMessage(iter.Type()) {
 				continue
 			}
+			// Raw message is valid until the next Next, saver may keep only the decoded one (it's never released)
 			msg := iter.Message().Decode()
 			if msg == nil {
-				return
+				continue
 			}
 
 			// Just save session data into db without additional checks
@@ -120,11 +131,14 @@ func main() {
 		cfg.GroupDB,
 		[]string{
 			cfg.TopicRawWeb,
+			cfg.TopicRawIOS,
+			cfg.TopicRawAndroid,
 			cfg.TopicAnalytics,
 		},
 		handler,
 		false,
 		cfg.MessageSizeLimit,
+		metrics,
 	)
 
 	log.Printf("Db service started\n")
@@ -137,6 +151,7 @@ func main() {
 		select {
 		case sig := <-sigchan:
 			log.Printf("Caught signal %v: terminating\n", sig)
+			healthCheck.Drain(cfg.HealthDrainTimeout)
 			consumer.Close()
 			os.Exit(0)
 		case <-commitTick:
@@ -162,6 +177,7 @@ func main() {
 			if err != nil {
 				log.Fatalf("Error on consumption: %v", err)
 			}
+			healthCheck.Consumed()
 		}
 	}
 }
@@ -12,11 +12,13 @@ import (
 	"openreplay/backend/internal/sessionender"
 	"openreplay/backend/pkg/db/cache"
 	"openreplay/backend/pkg/db/postgres"
+	"openreplay/backend/pkg/health"
 	"openreplay/backend/pkg/intervals"
 	logger "openreplay/backend/pkg/log"
 	"openreplay/backend/pkg/messages"
 	"openreplay/backend/pkg/monitoring"
 	"openreplay/backend/pkg/queue"
+	"openreplay/backend/pkg/redisstream"
 )
 
 func main() {
@@ -27,7 +29,13 @@ func main() {
 	// Load service configuration
 	cfg := ender.New()
 
+	healthCheck := health.New("ender", cfg.HealthConsumerTimeout)
+	if redisstream.GetClient() != nil {
+		healthCheck.AddCheck("redis", redisstream.Ping)
+	}
+
 	pg := cache.NewPGCache(postgres.NewConn(cfg.Postgres, 0, 0, metrics), cfg.ProjectExpirationTimeoutMs)
+	healthCheck.AddCheck("postgres", pg.Ping)
 	defer pg.Close()
 
 	// Init all modules
@@ -45,7 +53,14 @@ func main() {
 		},
 		func(sessionID uint64, iter messages.Iterator, meta *types.Meta) {
 			for iter.Next() {
-				if iter.Type() == messages.MsgSessionStart || iter.Type() == messages.MsgSessionEnd {
+				if iter.Type() == messages.MsgSessionClockSkew {
+					if m, ok := iter.Message().Decode().(*messages.SessionClockSkew); ok {
+						sessions.SetClockSkew(sessionID, m.ClockSkew)
+						messages.ReleaseMessage(m)
+					}
+					continue
+				}
+				if iter.Type() == messages.MsgSessionStart || iter.Type() == messages.MsgSessionEnd || iter.Type() == messages.MsgSessionGeo {
 					continue
 				}
 				if iter.Message().Meta().Timestamp == 0 {
@@ -58,6 +73,7 @@ func main() {
 		},
 		false,
 		cfg.MessageSizeLimit,
+		metrics,
 	)
 
 	log.Printf("Ender service started\n")
@@ -70,6 +86,7 @@ func main() {
 		select {
 		case sig := <-sigchan:
 			log.Printf("Caught signal %v: terminating\n", sig)
+			healthCheck.Drain(cfg.HealthDrainTimeout)
 			producer.Close(cfg.ProducerTimeout)
 			if err := consumer.CommitBack(intervals.EVENTS_BACK_COMMIT_GAP); err != nil {
 				log.Printf("can't commit messages with offset: %s", err)
@@ -108,6 +125,7 @@ func main() {
 			if err := consumer.ConsumeNext(); err != nil {
 				log.Fatalf("Error on consuming: %v", err)
 			}
+			healthCheck.Consumed()
 		}
 	}
 }
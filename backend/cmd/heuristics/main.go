@@ -11,19 +11,29 @@ import (
 	"openreplay/backend/internal/config/heuristics"
 	"openreplay/backend/pkg/handlers"
 	web2 "openreplay/backend/pkg/handlers/web"
+	"openreplay/backend/pkg/health"
 	"openreplay/backend/pkg/intervals"
 	logger "openreplay/backend/pkg/log"
 	"openreplay/backend/pkg/messages"
+	"openreplay/backend/pkg/monitoring"
 	"openreplay/backend/pkg/queue"
+	"openreplay/backend/pkg/redisstream"
 	"openreplay/backend/pkg/sessions"
 )
 
 func main() {
+	metrics := monitoring.New("heuristics")
+
 	log.SetFlags(log.LstdFlags | log.LUTC | log.Llongfile)
 
 	// Load service configuration
 	cfg := heuristics.New()
 
+	healthCheck := health.New("heuristics", cfg.HealthConsumerTimeout)
+	if redisstream.GetClient() != nil {
+		healthCheck.AddCheck("redis", redisstream.Ping)
+	}
+
 	// HandlersFabric returns the list of message handlers we want to be applied to each incoming message.
 	handlersFabric := func() []handlers.MessageProcessor {
 		return []handlers.MessageProcessor{
@@ -56,6 +66,7 @@ func main() {
 			var lastMessageID uint64
 			for iter.Next() {
 				statsLogger.Collect(sessionID, meta)
+				// Raw message is valid until the next Next, builders keep only the decoded one (it's never released)
 				msg := iter.Message().Decode()
 				if msg == nil {
 					log.Printf("failed batch, sess: %d, lastIndex: %d", sessionID, lastMessageID)
@@ -68,6 +79,7 @@ func main() {
 		},
 		false,
 		cfg.MessageSizeLimit,
+		metrics,
 	)
 
 	log.Printf("Heuristics service started\n")
@@ -80,6 +92,7 @@ func main() {
 		select {
 		case sig := <-sigchan:
 			log.Printf("Caught signal %v: terminating\n", sig)
+			healthCheck.Drain(cfg.HealthDrainTimeout)
 			producer.Close(cfg.ProducerTimeout)
 			consumer.Commit()
 			consumer.Close()
@@ -94,6 +107,7 @@ func main() {
 			if err := consumer.ConsumeNext(); err != nil {
 				log.Fatalf("Error on consuming: %v", err)
 			}
+			healthCheck.Consumed()
 		}
 	}
 }
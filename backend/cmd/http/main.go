@@ -6,7 +6,10 @@ import (
 	"openreplay/backend/internal/http/router"
 	"openreplay/backend/internal/http/server"
 	"openreplay/backend/internal/http/services"
+	"openreplay/backend/internal/http/spool"
+	"openreplay/backend/pkg/health"
 	"openreplay/backend/pkg/monitoring"
+	"openreplay/backend/pkg/redisstream"
 	"os"
 	"os/signal"
 	"syscall"
@@ -14,6 +17,7 @@ import (
 	"openreplay/backend/pkg/db/cache"
 	"openreplay/backend/pkg/db/postgres"
 	"openreplay/backend/pkg/queue"
+	"openreplay/backend/pkg/queue/types"
 )
 
 func main() {
@@ -23,16 +27,26 @@ func main() {
 
 	cfg := http.New()
 
-	// Connect to queue
-	producer := queue.NewProducer(cfg.MessageSizeLimit, true)
+	healthCheck := health.New("http", cfg.HealthConsumerTimeout)
+	if redisstream.GetClient() != nil {
+		healthCheck.AddCheck("redis", redisstream.Ping)
+	}
+
+	// Connect to queue, batches which can't be sent are kept on the disk, large ones are sent in chunks
+	// The producer is synchronous, otherwise delivery errors never reach the spool
+	producer := spool.New(cfg, func(onFailed types.DeliveryFailureHandler) types.Producer {
+		return queue.NewPartitionedProducer(queue.NewReportingProducer(cfg.MessageSizeLimit, onFailed), cfg.MessageSizeLimit)
+	}, metrics)
 	defer producer.Close(15000)
 
 	// Connect to database
 	dbConn := cache.NewPGCache(postgres.NewConn(cfg.Postgres, 0, 0, metrics), 1000*60*20)
 	defer dbConn.Close()
+	healthCheck.AddCheck("postgres", dbConn.Ping)
 
 	// Build all services
-	services := services.New(cfg, producer, dbConn)
+	services := services.New(cfg, producer, dbConn, metrics)
+	healthCheck.AddOptionalCheck("s3", services.Images.Ping) // Only iOS screenshots are stored in S3
 
 	// Init server's routes
 	router, err := router.NewRouter(cfg, services, metrics)
@@ -60,5 +74,6 @@ func main() {
 	signal.Notify(sigchan, syscall.SIGINT, syscall.SIGTERM)
 	<-sigchan
 	log.Printf("Shutting down the server\n")
+	healthCheck.Drain(cfg.HealthDrainTimeout)
 	server.Stop()
 }
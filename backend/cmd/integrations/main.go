@@ -4,7 +4,9 @@ import (
 	"log"
 	config "openreplay/backend/internal/config/integrations"
 	"openreplay/backend/internal/integrations/clientManager"
+	"openreplay/backend/pkg/health"
 	"openreplay/backend/pkg/monitoring"
+	"openreplay/backend/pkg/redisstream"
 	"time"
 
 	"os"
@@ -26,10 +28,16 @@ func main() {
 
 	cfg := config.New()
 
+	healthCheck := health.New("integrations", cfg.HealthConsumerTimeout)
+	if redisstream.GetClient() != nil {
+		healthCheck.AddCheck("redis", redisstream.Ping)
+	}
+
 	pg := postgres.NewConn(cfg.PostgresURI, 0, 0, metrics)
 	defer pg.Close()
+	healthCheck.AddCheck("postgres", pg.Ping)
 
-	tokenizer := token.NewTokenizer(cfg.TokenSecret)
+	tokenizer := token.NewTokenizer(cfg.TokenSecrets, cfg.TokenSecret, false, metrics)
 
 	manager := clientManager.NewManager()
 
@@ -67,6 +75,7 @@ func main() {
 		select {
 		case sig := <-sigchan:
 			log.Printf("Caught signal %v: terminating\n", sig)
+			healthCheck.Drain(cfg.HealthDrainTimeout)
 			listener.Close()
 			pg.Close()
 			os.Exit(0)
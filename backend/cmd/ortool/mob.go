@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"openreplay/backend/pkg/messages"
+	"openreplay/backend/pkg/storage"
+)
+
+// mobRecord is a message of the session file with information about its position
+type mobRecord struct {
+	msg       messages.Message
+	index     uint64
+	timestamp int64 // value of the last Timestamp message
+	offset    int
+	size      int // including 8 bytes of the index (web only)
+}
+
+func (r *mobRecord) typeName() string {
+	if name := messages.TypeName(r.msg.TypeID()); name != "" {
+		return name
+	}
+	return strconv.Itoa(r.msg.TypeID())
+}
+
+// Usage: ortool mob [flags] <session file or session ID>
+func mobCommand(args []string) {
+	fs := flag.NewFlagSet("mob", flag.ExitOnError)
+	bucket := fs.String("bucket", os.Getenv("S3_BUCKET_WEB"), "S3 bucket with session files, used if the argument isn't a local file")
+	region := fs.String("region", os.Getenv("AWS_REGION_WEB"), "region of the S3 bucket")
+	typeList := fs.String("type", "", "comma separated names or IDs of message types to keep")
+	from := fs.String("from", "", "keep messages since this time: unix timestamp in ms or duration since the first timestamp, e.g. 90s")
+	to := fs.String("to", "", "keep messages until this time, same format as -from")
+	stats := fs.Bool("stats", false, "print count and size of messages per type instead of the list")
+	asJSON := fs.Bool("json", false, "print messages as JSON lines")
+	out := fs.String("out", "", "write kept messages to the new (uncompressed) session file, Timestamp messages are always kept")
+	ios := fs.Bool("ios", false, "file of iOS session, messages don't have the index (detected by the first message if not set)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: ortool mob [flags] <session file or session ID>\n\n"+
+			"The end part of the file (<file>e) is read as well if it exists, gzipped parts are unpacked.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	data, err := loadMob(fs.Arg(0), *bucket, *region)
+	if err != nil {
+		log.Fatalf("can't load session file: %s", err)
+	}
+	indexed := !*ios && !isIOSMob(data)
+	records, parseErr := parseMob(data, indexed)
+	if parseErr != nil && !indexed && !*ios {
+		// Index of the first web message might look like iOS message type
+		if webRecords, err := parseMob(data, true); err == nil {
+			records, parseErr = webRecords, nil
+		}
+	}
+	if parseErr != nil {
+		log.Printf("WARNING: %s, only %d messages were read", parseErr, len(records))
+	}
+
+	keep, err := newMobFilter(*typeList, *from, *to, records, *out != "")
+	if err != nil {
+		log.Fatalf("wrong filter: %s", err)
+	}
+	kept := make([]*mobRecord, 0, len(records))
+	for _, rec := range records {
+		if keep(rec) {
+			kept = append(kept, rec)
+		}
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	switch {
+	case *stats:
+		printMobStats(w, kept)
+	case *asJSON:
+		for _, rec := range kept {
+			line, err := messages.EncodeJSON(rec.msg)
+			if err != nil {
+				log.Fatalf("can't encode message with index %d: %s", rec.index, err)
+			}
+			w.Write(line)
+			w.WriteByte('\n')
+		}
+	case *out == "":
+		fmt.Fprintf(w, "%-20s %-14s %-10s %-8s %s\n", "INDEX", "TIMESTAMP", "OFFSET", "SIZE", "TYPE")
+		for _, rec := range kept {
+			fmt.Fprintf(w, "%-20d %-14d %-10d %-8d %s\n", rec.index, rec.timestamp, rec.offset, rec.size, rec.typeName())
+		}
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatalf("can't write output: %s", err)
+	}
+
+	if *out != "" {
+		if err := writeMob(*out, kept); err != nil {
+			log.Fatalf("can't write session file: %s", err)
+		}
+		log.Printf("%d of %d messages were written to %s", len(kept), len(records), *out)
+	}
+	if parseErr != nil {
+		os.Exit(1)
+	}
+}
+
+// loadMob reads the start and the end parts of the session file from the disk or from S3 by session ID
+func loadMob(source, bucket, region string) ([]byte, error) {
+	if _, err := os.Stat(source); err == nil {
+		start, err := readPart(os.Open(source))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(source + "e"); err != nil {
+			return start, nil
+		}
+		end, err := readPart(os.Open(source + "e"))
+		if err != nil {
+			return nil, err
+		}
+		return append(start, end...), nil
+	}
+	if _, err := strconv.ParseUint(source, 10, 64); err != nil {
+		return nil, fmt.Errorf("%s is neither a file nor a session ID", source)
+	}
+	if bucket == "" {
+		return nil, errors.New("bucket is required to download the session")
+	}
+	s3 := storage.NewS3(region, bucket)
+	start, err := readPart(s3.Get(source))
+	if err != nil {
+		return nil, err
+	}
+	if !s3.Exists(source + "e") {
+		return start, nil
+	}
+	end, err := readPart(s3.Get(source + "e"))
+	if err != nil {
+		return nil, err
+	}
+	return append(start, end...), nil
+}
+
+// readPart reads the whole part of the session file, unpacking it if it's gzipped
+func readPart(file io.ReadCloser, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("can't unpack gzip: %s", err)
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// isIOSMob checks the type of the first message, iOS session files consist of iOS messages only
+func isIOSMob(data []byte) bool {
+	msgType, err := messages.ReadUint(bytes.NewReader(data))
+	return err == nil && messages.IsIOSType(int(msgType))
+}
+
+// parseMob reads messages written with EncodeWithIndex, messages before the broken one are returned with the error.
+// iOS messages are written without the index, so their index is the position in the file.
+func parseMob(data []byte, indexed bool) ([]*mobRecord, error) {
+	var (
+		records   []*mobRecord
+		timestamp int64
+	)
+	reader := bytes.NewReader(data)
+	for reader.Len() > 0 {
+		offset := len(data) - reader.Len()
+		index := uint64(len(records))
+		if indexed {
+			if reader.Len() < 8 {
+				return records, fmt.Errorf("truncated index at offset %d", offset)
+			}
+			var buf [8]byte
+			reader.Read(buf[:])
+			index = binary.LittleEndian.Uint64(buf[:])
+		}
+		msgType, err := messages.ReadUint(reader)
+		if err != nil {
+			return records, fmt.Errorf("can't read message type at offset %d: %s", offset, err)
+		}
+		msg, err := messages.ReadMessage(msgType, reader)
+		if err != nil {
+			return records, fmt.Errorf("can't read message of type %d at offset %d: %s", msgType, offset, err)
+		}
+		if m, ok := msg.(*messages.Timestamp); ok {
+			timestamp = int64(m.Timestamp)
+		} else if ts := messages.GetTimestamp(msg); !indexed && ts != 0 { // iOS messages have their own timestamps
+			timestamp = int64(ts)
+		}
+		msg.Meta().Index = index
+		msg.Meta().Timestamp = timestamp
+		records = append(records, &mobRecord{
+			msg:       msg,
+			index:     msg.Meta().Index,
+			timestamp: timestamp,
+			offset:    offset,
+			size:      len(data) - reader.Len() - offset,
+		})
+	}
+	return records, nil
+}
+
+// newMobFilter returns the function which checks message type and time range of records.
+// Timestamp messages are kept by the type filter if keepTimestamps is set, player needs them to place other messages.
+func newMobFilter(typeList, from, to string, records []*mobRecord, keepTimestamps bool) (func(*mobRecord) bool, error) {
+	var types map[int]bool
+	if typeList != "" {
+		types = make(map[int]bool)
+		for _, name := range strings.Split(typeList, ",") {
+			name = strings.TrimSpace(name)
+			if id, err := strconv.Atoi(name); err == nil {
+				types[id] = true
+			} else if id, ok := messages.TypeIDByName(name); ok {
+				types[id] = true
+			} else {
+				return nil, fmt.Errorf("unknown message type: %s", name)
+			}
+		}
+	}
+	var firstTimestamp int64
+	for _, rec := range records {
+		if rec.timestamp != 0 {
+			firstTimestamp = rec.timestamp
+			break
+		}
+	}
+	fromTS, err := parseTimeBound(from, firstTimestamp, 0)
+	if err != nil {
+		return nil, err
+	}
+	toTS, err := parseTimeBound(to, firstTimestamp, 1<<62)
+	if err != nil {
+		return nil, err
+	}
+	return func(rec *mobRecord) bool {
+		if types != nil && !types[rec.msg.TypeID()] && !(keepTimestamps && rec.msg.TypeID() == messages.MsgTimestamp) {
+			return false
+		}
+		return rec.timestamp >= fromTS && rec.timestamp <= toTS
+	}, nil
+}
+
+// parseTimeBound parses unix timestamp in ms or duration since the first timestamp of the session
+func parseTimeBound(value string, firstTimestamp, defaultValue int64) (int64, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return ts, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s is neither a timestamp nor a duration", value)
+	}
+	return firstTimestamp + d.Milliseconds(), nil
+}
+
+func printMobStats(w io.Writer, records []*mobRecord) {
+	type typeStats struct {
+		name  string
+		count int
+		size  int
+	}
+	byType := make(map[int]*typeStats)
+	total := &typeStats{name: "TOTAL"}
+	for _, rec := range records {
+		st, ok := byType[rec.msg.TypeID()]
+		if !ok {
+			st = &typeStats{name: rec.typeName()}
+			byType[rec.msg.TypeID()] = st
+		}
+		st.count++
+		st.size += rec.size
+		total.count++
+		total.size += rec.size
+	}
+	list := make([]*typeStats, 0, len(byType)+1)
+	for _, st := range byType {
+		list = append(list, st)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].size > list[j].size
+	})
+	list = append(list, total)
+	fmt.Fprintf(w, "%-32s %-10s %s\n", "TYPE", "COUNT", "BYTES")
+	for _, st := range list {
+		fmt.Fprintf(w, "%-32s %-10d %d\n", st.name, st.count, st.size)
+	}
+}
+
+// writeMob encodes records back in the format of sink
+func writeMob(path string, records []*mobRecord) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(file)
+	for _, rec := range records {
+		if _, err := w.Write(rec.msg.EncodeWithIndex()); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"openreplay/backend/pkg/messages"
+	"openreplay/backend/pkg/rrweb"
+)
+
+type metadataFlag map[string]string
+
+func (m metadataFlag) String() string {
+	return fmt.Sprint(map[string]string(m))
+}
+
+func (m metadataFlag) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 {
+		return fmt.Errorf("metadata should be in key=value format")
+	}
+	m[kv[0]] = kv[1]
+	return nil
+}
+
+// Usage: rrweb-import -endpoint https://openreplay.example.com/ingest -key <project secret key> recording.json ...
+func main() {
+	log.SetFlags(log.LstdFlags | log.LUTC)
+
+	endpoint := flag.String("endpoint", os.Getenv("OPENREPLAY_INGEST"), "URL of the http service")
+	secretKey := flag.String("key", os.Getenv("OPENREPLAY_SECRET_KEY"), "project secret key")
+	userAgent := flag.String("user-agent", "", "user agent of the recorded browser")
+	userID := flag.String("user-id", "", "user ID of the sessions")
+	country := flag.String("country", "", "ISO code of user's country")
+	dryRun := flag.Bool("dry-run", false, "only convert recordings and print statistics")
+	metadata := metadataFlag{}
+	flag.Var(metadata, "metadata", "session metadata as key=value, might be repeated")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		log.Fatalf("no recordings to import")
+	}
+	if !*dryRun && (*endpoint == "" || *secretKey == "") {
+		log.Fatalf("endpoint and secret key are required")
+	}
+
+	client := &http.Client{Timeout: 5 * time.Minute}
+	failed := 0
+	for _, file := range flag.Args() {
+		events, err := readRecording(file)
+		if err != nil {
+			log.Printf("can't read %s: %s", file, err)
+			failed++
+			continue
+		}
+		msgs, err := rrweb.Convert(events)
+		if err != nil {
+			log.Printf("can't convert %s: %s", file, err)
+			failed++
+			continue
+		}
+		if *dryRun {
+			log.Printf("%s: %d events, %d messages, %d batches", file, len(events), len(msgs),
+				len(messages.EncodeBatches(msgs, 1, 1000000)))
+			continue
+		}
+		sessionID, err := send(client, *endpoint, *secretKey, map[string]interface{}{
+			"events":      events,
+			"userAgent":   *userAgent,
+			"userID":      *userID,
+			"userCountry": *country,
+			"metadata":    metadata,
+		})
+		if err != nil {
+			log.Printf("can't import %s: %s", file, err)
+			failed++
+			continue
+		}
+		log.Printf("%s imported as session %s", file, sessionID)
+	}
+	if failed > 0 {
+		log.Fatalf("%d of %d recordings failed", failed, flag.NArg())
+	}
+}
+
+// readRecording accepts both an array of events and an object with events field
+func readRecording(file string) ([]*rrweb.Event, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	var events []*rrweb.Event
+	if len(data) > 0 && data[0] == '{' {
+		recording := struct {
+			Events []*rrweb.Event `json:"events"`
+		}{}
+		err = json.Unmarshal(data, &recording)
+		events = recording.Events
+	} else {
+		err = json.Unmarshal(data, &events)
+	}
+	return events, err
+}
+
+func send(client *http.Client, endpoint, secretKey string, req interface{}) (string, error) {
+	body := &bytes.Buffer{}
+	zw := gzip.NewWriter(body)
+	if err := json.NewEncoder(zw).Encode(req); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(endpoint, "/")+"/v1/web/import", body)
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+secretKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Content-Encoding", "gzip")
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status %d: %s", resp.StatusCode, respBody)
+	}
+	res := struct {
+		SessionID string `json:"sessionID"`
+	}{}
+	if err := json.Unmarshal(respBody, &res); err != nil {
+		return "", err
+	}
+	return res.SessionID, nil
+}
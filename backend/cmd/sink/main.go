@@ -13,9 +13,11 @@ import (
 	"openreplay/backend/internal/sink/assetscache"
 	"openreplay/backend/internal/sink/oswriter"
 	"openreplay/backend/internal/storage"
+	"openreplay/backend/pkg/health"
 	. "openreplay/backend/pkg/messages"
 	"openreplay/backend/pkg/monitoring"
 	"openreplay/backend/pkg/queue"
+	"openreplay/backend/pkg/redisstream"
 	"openreplay/backend/pkg/url/assets"
 )
 
@@ -26,6 +28,11 @@ func main() {
 
 	cfg := sink.New()
 
+	healthCheck := health.New("sink", cfg.HealthConsumerTimeout)
+	if redisstream.GetClient() != nil {
+		healthCheck.AddCheck("redis", redisstream.Ping)
+	}
+
 	if _, err := os.Stat(cfg.FsDir); os.IsNotExist(err) {
 		log.Fatalf("%v doesn't exist. %v", cfg.FsDir, err)
 	}
@@ -55,15 +62,25 @@ func main() {
 		cfg.GroupSink,
 		[]string{
 			cfg.TopicRawWeb,
+			cfg.TopicRawIOS,
 		},
 		func(sessionID uint64, iter Iterator, meta *types.Meta) {
+			// iOS messages are written without index and have their own set of replayer types and session end
+			isReplayerType, sessionEndType := IsReplayerType, MsgSessionEnd
+			if meta.Topic == cfg.TopicRawIOS {
+				isReplayerType, sessionEndType = IsIOSReplayerType, MsgIOSSessionEnd
+			}
 			for iter.Next() {
 				// [METRICS] Increase the number of processed messages
 				totalMessages.Add(context.Background(), 1)
 
 				// Send SessionEnd trigger to storage service
-				if iter.Type() == MsgSessionEnd {
-					if err := producer.Produce(cfg.TopicTrigger, sessionID, iter.Message().Encode()); err != nil {
+				if iter.Type() == sessionEndType {
+					trigger := iter.Message().Encode()
+					if m, ok := iter.Message().Decode().(*IOSSessionEnd); ok {
+						trigger = Encode(&SessionEnd{Timestamp: m.Timestamp})
+					}
+					if err := producer.Produce(cfg.TopicTrigger, sessionID, trigger); err != nil {
 						log.Printf("can't send SessionEnd to trigger topic: %s; sessID: %d", err, sessionID)
 					}
 					continue
@@ -71,25 +88,26 @@ func main() {
 
 				msg := iter.Message()
 				// Process assets
+				var decoded Message
 				if iter.Type() == MsgSetNodeAttributeURLBased ||
 					iter.Type() == MsgSetCSSDataURLBased ||
 					iter.Type() == MsgCSSInsertRuleURLBased ||
 					iter.Type() == MsgAdoptedSSReplaceURLBased ||
 					iter.Type() == MsgAdoptedSSInsertRuleURLBased {
-					m := msg.Decode()
-					if m == nil {
-						return
+					decoded = msg.Decode()
+					if decoded == nil {
+						continue
 					}
-					msg = assetMessageHandler.ParseAssets(sessionID, m) // TODO: filter type only once (use iterator inide or bring ParseAssets out here).
+					msg = assetMessageHandler.ParseAssets(sessionID, decoded) // TODO: filter type only once (use iterator inide or bring ParseAssets out here).
 				}
 
 				// Filter message
-				if !IsReplayerType(msg.TypeID()) {
+				if !isReplayerType(msg.TypeID()) {
 					continue
 				}
 
 				// If message timestamp is empty, use at least ts of session start
-				ts := msg.Meta().Timestamp
+				ts := int64(GetTimestamp(msg))
 				if ts == 0 {
 					log.Printf("zero ts; sessID: %d, msgType: %d", sessionID, iter.Type())
 				} else {
@@ -97,20 +115,23 @@ func main() {
 					counter.Update(sessionID, time.UnixMilli(ts))
 				}
 
-				// Write encoded message with index to session file
-				data := msg.EncodeWithIndex()
-				if err := writer.Write(sessionID, data); err != nil {
+				// Write encoded message with index to session file, msg is valid until the next Next, so it's written right away
+				size, err := writer.WriteMessage(sessionID, msg)
+				if err != nil {
 					log.Printf("Writer error: %v\n", err)
 				}
+				// Decoded message isn't used after writing
+				ReleaseMessage(decoded)
 
 				// [METRICS] Increase the number of written to the files messages and the message size
-				messageSize.Record(context.Background(), float64(len(data)))
+				messageSize.Record(context.Background(), float64(size))
 				savedMessages.Add(context.Background(), 1)
 			}
 			iter.Close()
 		},
 		false,
 		cfg.MessageSizeLimit,
+		metrics,
 	)
 	log.Printf("Sink service started\n")
 
@@ -122,6 +143,7 @@ func main() {
 		select {
 		case sig := <-sigchan:
 			log.Printf("Caught signal %v: terminating\n", sig)
+			healthCheck.Drain(cfg.HealthDrainTimeout)
 			if err := consumer.Commit(); err != nil {
 				log.Printf("can't commit messages: %s", err)
 			}
@@ -140,6 +162,7 @@ func main() {
 			if err != nil {
 				log.Fatalf("Error on consumption: %v", err)
 			}
+			healthCheck.Consumed()
 		}
 	}
 
@@ -12,9 +12,11 @@ import (
 	config "openreplay/backend/internal/config/storage"
 	"openreplay/backend/internal/storage"
 	"openreplay/backend/pkg/failover"
+	"openreplay/backend/pkg/health"
 	"openreplay/backend/pkg/messages"
 	"openreplay/backend/pkg/monitoring"
 	"openreplay/backend/pkg/queue"
+	"openreplay/backend/pkg/redisstream"
 	s3storage "openreplay/backend/pkg/storage"
 )
 
@@ -25,7 +27,13 @@ func main() {
 
 	cfg := config.New()
 
+	healthCheck := health.New("storage", cfg.HealthConsumerTimeout)
+	if redisstream.GetClient() != nil {
+		healthCheck.AddCheck("redis", redisstream.Ping)
+	}
+
 	s3 := s3storage.NewS3(cfg.S3Region, cfg.S3Bucket)
+	healthCheck.AddCheck("s3", s3.Ping)
 	srv, err := storage.New(cfg, s3, metrics)
 	if err != nil {
 		log.Printf("can't init storage service: %s", err)
@@ -33,7 +41,7 @@ func main() {
 	}
 
 	counter := storage.NewLogCounter()
-	sessionFinder, err := failover.NewSessionFinder(cfg, srv)
+	sessionFinder, err := failover.NewSessionFinder(cfg, srv, metrics)
 	if err != nil {
 		log.Fatalf("can't init sessionFinder module: %s", err)
 	}
@@ -45,6 +53,7 @@ func main() {
 		},
 		func(sessionID uint64, iter messages.Iterator, meta *types.Meta) {
 			for iter.Next() {
+				// Sink sends SessionEnd for the sessions of all platforms
 				if iter.Type() == messages.MsgSessionEnd {
 					msg := iter.Message().Decode().(*messages.SessionEnd)
 					if err := srv.UploadKey(strconv.FormatUint(sessionID, 10), 5); err != nil {
@@ -58,6 +67,7 @@ func main() {
 		},
 		true,
 		cfg.MessageSizeLimit,
+		metrics,
 	)
 
 	log.Printf("Storage service started\n")
@@ -70,6 +80,7 @@ func main() {
 		select {
 		case sig := <-sigchan:
 			log.Printf("Caught signal %v: terminating\n", sig)
+			healthCheck.Drain(cfg.HealthDrainTimeout)
 			sessionFinder.Stop()
 			consumer.Close()
 			os.Exit(0)
@@ -80,6 +91,7 @@ func main() {
 			if err != nil {
 				log.Fatalf("Error on consumption: %v", err)
 			}
+			healthCheck.Consumed()
 		}
 	}
 }
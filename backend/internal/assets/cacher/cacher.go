@@ -35,6 +35,11 @@ type cacher struct {
 	requestHeaders   map[string]string
 }
 
+// Ping checks that the assets bucket is accessible
+func (c *cacher) Ping() error {
+	return c.s3.Ping()
+}
+
 func NewCacher(cfg *config.Config, metrics *monitoring.Metrics) *cacher {
 	rewriter := assets.NewRewriter(cfg.AssetsOrigin)
 	if metrics == nil {
@@ -1,8 +1,12 @@
 package common
 
+import "time"
+
 type Config struct {
-	ConfigFilePath   string `env:"CONFIG_FILE_PATH"`
-	MessageSizeLimit int    `env:"QUEUE_MESSAGE_SIZE_LIMIT,default=1048576"`
+	ConfigFilePath        string        `env:"CONFIG_FILE_PATH"`
+	MessageSizeLimit      int           `env:"QUEUE_MESSAGE_SIZE_LIMIT,default=1048576"`
+	HealthConsumerTimeout time.Duration `env:"HEALTH_CONSUMER_TIMEOUT,default=60s"` // Liveness fails if consumer is stuck for longer
+	HealthDrainTimeout    time.Duration `env:"HEALTH_DRAIN_TIMEOUT,default=15s"`    // Wait after readiness starts failing, must be longer than readiness period
 }
 
 type Configer interface {
@@ -13,6 +13,8 @@ type Config struct {
 	LoggerTimeout              int           `env:"LOG_QUEUE_STATS_INTERVAL_SEC,required"`
 	GroupDB                    string        `env:"GROUP_DB,required"`
 	TopicRawWeb                string        `env:"TOPIC_RAW_WEB,required"`
+	TopicRawIOS                string        `env:"TOPIC_RAW_IOS,required"`
+	TopicRawAndroid            string        `env:"TOPIC_RAW_ANDROID,required"`
 	TopicAnalytics             string        `env:"TOPIC_ANALYTICS,required"`
 	CommitBatchTimeout         time.Duration `env:"COMMIT_BATCH_TIMEOUT,default=15s"`
 	BatchQueueLimit            int           `env:"DB_BATCH_QUEUE_LIMIT,required"`
@@ -14,16 +14,36 @@ type Config struct {
 	HTTPTimeout       time.Duration `env:"HTTP_TIMEOUT,default=60s"`
 	TopicRawWeb       string        `env:"TOPIC_RAW_WEB,required"`
 	TopicRawIOS       string        `env:"TOPIC_RAW_IOS,required"`
+	TopicRawAndroid   string        `env:"TOPIC_RAW_ANDROID,required"`
 	BeaconSizeLimit   int64         `env:"BEACON_SIZE_LIMIT,required"`
 	JsonSizeLimit     int64         `env:"JSON_SIZE_LIMIT,default=1000"`
 	FileSizeLimit     int64         `env:"FILE_SIZE_LIMIT,default=10000000"`
+	ImportSizeLimit   int64         `env:"IMPORT_SIZE_LIMIT,default=100000000"`
 	AWSRegion         string        `env:"AWS_REGION,required"`
 	S3BucketIOSImages string        `env:"S3_BUCKET_IOS_IMAGES,required"`
+	ImagesHashListTTL time.Duration `env:"IMAGES_HASH_LIST_TTL,default=5m"`
 	Postgres          string        `env:"POSTGRES_STRING,required"`
-	TokenSecret       string        `env:"TOKEN_SECRET,required"`
+	TokenSecret       string        `env:"TOKEN_SECRET,default="`
+	TokenSecrets      string        `env:"TOKEN_SECRETS,default="`         // id1:secret1,id2:secret2, the first one signs new tokens
+	TokenProjectID    bool          `env:"TOKEN_PROJECT_ID,default=false"` // enable after all the services parse tokens with project ID
 	UAParserFile      string        `env:"UAPARSER_FILE,required"`
 	MaxMinDBFile      string        `env:"MAXMINDDB_FILE,required"`
+	MaxMindASNFile    string        `env:"MAXMINDDB_ASN_FILE,default="`
+	ScrubMask         string        `env:"SCRUB_MASK,default=***"`
 	WorkerID          uint16
+
+	// Batches which can't be sent to the queue are kept on the disk
+	SpoolDir            string        `env:"SPOOL_DIR,default=/home/openreplay/spool"`
+	SpoolSizeLimit      int64         `env:"SPOOL_SIZE_LIMIT,default=1000000000"`
+	SpoolReplayInterval time.Duration `env:"SPOOL_REPLAY_INTERVAL,default=1s"`
+	SpoolRetryAfter     time.Duration `env:"SPOOL_RETRY_AFTER,default=30s"`
+
+	// Per project ingest limits, 0 means no limit
+	SessionsRateLimit    int   `env:"RATE_LIMIT_SESSIONS,default=0"` // session starts per second
+	SessionsRateBurst    int   `env:"RATE_LIMIT_SESSIONS_BURST,default=100"`
+	BytesRateLimit       int64 `env:"RATE_LIMIT_BYTES,default=0"` // pushed bytes per second
+	BytesRateBurst       int64 `env:"RATE_LIMIT_BYTES_BURST,default=10000000"`
+	MonthlySessionsQuota int64 `env:"MONTHLY_SESSIONS_QUOTA,default=0"`
 }
 
 func New() *Config {
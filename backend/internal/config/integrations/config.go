@@ -9,7 +9,8 @@ type Config struct {
 	common.Config
 	TopicAnalytics string `env:"TOPIC_ANALYTICS,required"`
 	PostgresURI    string `env:"POSTGRES_STRING,required"`
-	TokenSecret    string `env:"TOKEN_SECRET,required"`
+	TokenSecret    string `env:"TOKEN_SECRET,default="`
+	TokenSecrets   string `env:"TOKEN_SECRETS,default="`
 }
 
 func New() *Config {
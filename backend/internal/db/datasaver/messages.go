@@ -5,6 +5,7 @@ import (
 	. "openreplay/backend/pkg/messages"
 )
 
+// InsertMessage may keep msg in the batches, so it must be a decoded message which isn't released to the pool
 func (mi *Saver) InsertMessage(sessionID uint64, msg Message) error {
 	switch m := msg.(type) {
 	// Common
@@ -15,6 +16,12 @@ func (mi *Saver) InsertMessage(sessionID uint64, msg Message) error {
 		return nil
 	case *IssueEvent:
 		return mi.pg.InsertIssueEvent(sessionID, m)
+	case *SessionAssociation:
+		return mi.pg.InsertSessionAssociation(sessionID, m.PreviousSessionID)
+	case *SessionGeo:
+		return mi.pg.InsertSessionGeo(sessionID, m)
+	case *SessionClockSkew:
+		return mi.pg.InsertSessionClockSkew(sessionID, m)
 	//TODO: message adapter (transformer) (at the level of pkg/message) for types: *IOSMetadata, *IOSIssueEvent and others
 
 	// Web
@@ -46,15 +53,24 @@ func (mi *Saver) InsertMessage(sessionID uint64, msg Message) error {
 		mi.sendToFTS(msg, sessionID)
 		return mi.pg.InsertWebGraphQLEvent(sessionID, m)
 	case *IntegrationEvent:
+		// Integration events come with backend timestamps, put them on the user's timeline
+		var clockSkew int64
+		if session, err := mi.pg.GetSession(sessionID); err == nil && session != nil {
+			clockSkew = session.ClockSkew
+		}
 		return mi.pg.InsertWebErrorEvent(sessionID, &ErrorEvent{
 			MessageID: m.Meta().Index,
-			Timestamp: m.Timestamp,
+			Timestamp: ToUserTime(m.Timestamp, clockSkew),
 			Source:    m.Source,
 			Name:      m.Name,
 			Message:   m.Message,
 			Payload:   m.Payload,
 		})
 
+		// Android
+	case *AndroidSessionStart:
+		return mi.pg.InsertAndroidSessionStart(sessionID, m)
+
 		// IOS
 	case *IOSSessionStart:
 		return mi.pg.InsertIOSSessionStart(sessionID, m)
@@ -1,6 +1,8 @@
 package datasaver
 
 import (
+	"openreplay/backend/pkg/health"
+
 	. "openreplay/backend/pkg/db/types"
 	. "openreplay/backend/pkg/messages"
 )
@@ -9,6 +11,10 @@ func (si *Saver) InitStats() {
 	// noop
 }
 
+func (si *Saver) AddHealthChecks(h *health.Health) {
+	// noop, stats are stored in postgres
+}
+
 func (si *Saver) InsertStats(session *Session, msg Message) error {
 	switch m := msg.(type) {
 	// Web
@@ -0,0 +1,120 @@
+package android
+
+import (
+	"strings"
+)
+
+// MapAndroidDevice returns the marketing name of the device by its Build.MODEL value
+func MapAndroidDevice(model string) string {
+	switch model {
+	case "Pixel 3", "Pixel 3 XL", "Pixel 3a", "Pixel 3a XL", "Pixel 4", "Pixel 4 XL", "Pixel 4a",
+		"Pixel 5", "Pixel 5a", "Pixel 6", "Pixel 6 Pro", "Pixel 6a":
+		return "Google " + model
+	case "SM-G960F", "SM-G960U", "SM-G960N":
+		return "Samsung Galaxy S9"
+	case "SM-G965F", "SM-G965U", "SM-G965N":
+		return "Samsung Galaxy S9+"
+	case "SM-G970F", "SM-G970U", "SM-G970N":
+		return "Samsung Galaxy S10e"
+	case "SM-G973F", "SM-G973U", "SM-G973N":
+		return "Samsung Galaxy S10"
+	case "SM-G975F", "SM-G975U", "SM-G975N":
+		return "Samsung Galaxy S10+"
+	case "SM-G980F", "SM-G981B", "SM-G981U", "SM-G981N":
+		return "Samsung Galaxy S20"
+	case "SM-G985F", "SM-G986B", "SM-G986U", "SM-G986N":
+		return "Samsung Galaxy S20+"
+	case "SM-G988B", "SM-G988U", "SM-G988N":
+		return "Samsung Galaxy S20 Ultra"
+	case "SM-G780F", "SM-G781B", "SM-G781U":
+		return "Samsung Galaxy S20 FE"
+	case "SM-G991B", "SM-G991U", "SM-G991N":
+		return "Samsung Galaxy S21"
+	case "SM-G996B", "SM-G996U", "SM-G996N":
+		return "Samsung Galaxy S21+"
+	case "SM-G998B", "SM-G998U", "SM-G998N":
+		return "Samsung Galaxy S21 Ultra"
+	case "SM-G990B", "SM-G990U", "SM-G990E":
+		return "Samsung Galaxy S21 FE"
+	case "SM-S901B", "SM-S901U", "SM-S901N":
+		return "Samsung Galaxy S22"
+	case "SM-S906B", "SM-S906U", "SM-S906N":
+		return "Samsung Galaxy S22+"
+	case "SM-S908B", "SM-S908U", "SM-S908N":
+		return "Samsung Galaxy S22 Ultra"
+	case "SM-N960F", "SM-N960U":
+		return "Samsung Galaxy Note9"
+	case "SM-N970F", "SM-N970U":
+		return "Samsung Galaxy Note10"
+	case "SM-N975F", "SM-N975U":
+		return "Samsung Galaxy Note10+"
+	case "SM-N980F", "SM-N981B", "SM-N981U":
+		return "Samsung Galaxy Note20"
+	case "SM-N985F", "SM-N986B", "SM-N986U":
+		return "Samsung Galaxy Note20 Ultra"
+	case "SM-A505F", "SM-A505FN", "SM-A505U":
+		return "Samsung Galaxy A50"
+	case "SM-A515F", "SM-A515U":
+		return "Samsung Galaxy A51"
+	case "SM-A525F", "SM-A525M":
+		return "Samsung Galaxy A52"
+	case "SM-A526B", "SM-A526U":
+		return "Samsung Galaxy A52 5G"
+	case "SM-A528B":
+		return "Samsung Galaxy A52s 5G"
+	case "SM-A125F", "SM-A125U":
+		return "Samsung Galaxy A12"
+	case "SM-A217F":
+		return "Samsung Galaxy A21s"
+	case "SM-A325F":
+		return "Samsung Galaxy A32"
+	case "SM-A536B", "SM-A536U":
+		return "Samsung Galaxy A53 5G"
+	case "SM-F711B", "SM-F711U":
+		return "Samsung Galaxy Z Flip3"
+	case "SM-F926B", "SM-F926U":
+		return "Samsung Galaxy Z Fold3"
+	case "SM-T500", "SM-T505":
+		return "Samsung Galaxy Tab A7"
+	case "SM-T870", "SM-T875":
+		return "Samsung Galaxy Tab S7"
+	case "SM-X700", "SM-X706B":
+		return "Samsung Galaxy Tab S8"
+	case "M2101K6G":
+		return "Xiaomi Redmi Note 10 Pro"
+	case "M2101K7AG":
+		return "Xiaomi Redmi Note 10"
+	case "M2007J20CG":
+		return "Xiaomi Poco X3 NFC"
+	case "M2102J20SG":
+		return "Xiaomi Poco X3 Pro"
+	case "M2011K2G":
+		return "Xiaomi Mi 11"
+	case "2201123G":
+		return "Xiaomi 12"
+	case "ONEPLUS A6003":
+		return "OnePlus 6"
+	case "ONEPLUS A6013":
+		return "OnePlus 6T"
+	case "GM1903", "GM1900":
+		return "OnePlus 7"
+	case "HD1903", "HD1900":
+		return "OnePlus 7T"
+	case "IN2013", "IN2010":
+		return "OnePlus 8"
+	case "LE2113", "LE2110":
+		return "OnePlus 9"
+	case "sdk_gphone_x86", "sdk_gphone_x86_64", "sdk_gphone64_x86_64", "sdk_gphone64_arm64", "Android SDK built for x86":
+		return "Emulator"
+	default:
+		return model
+	}
+}
+
+// GetAndroidDeviceType guesses the type by the model, SDK is expected to send the type based on the screen size
+func GetAndroidDeviceType(model string) string {
+	if strings.HasPrefix(model, "SM-T") || strings.HasPrefix(model, "SM-X") || strings.Contains(model, "Tab") {
+		return "tablet"
+	}
+	return "mobile"
+}
@@ -0,0 +1,108 @@
+package compression
+
+import (
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	gzip "github.com/klauspost/pgzip"
+)
+
+// Window size which zstd decoders are recommended to support
+const zstdMinWindow = 8 << 20
+
+var (
+	ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+	ErrTooLarge            = errors.New("request body is too large")
+)
+
+// limitReader marks the body which is longer than the limit, decoders might hide the original error
+type limitReader struct {
+	reader   io.Reader
+	left     int64
+	exceeded bool
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	n, err := l.reader.Read(p)
+	l.left -= int64(n)
+	if l.left < 0 {
+		l.exceeded = true
+		return n, ErrTooLarge
+	}
+	return n, err
+}
+
+// ReadAll decodes body according to Content-Encoding header value.
+// The limit applies to both the compressed and the decompressed size to protect from zip bombs.
+// ErrTooLarge is returned for bodies over the limit, any other error means the body is malformed.
+func ReadAll(body io.Reader, contentEncoding string, limit int64) ([]byte, error) {
+	limited := &limitReader{reader: body, left: limit}
+	var reader io.Reader = limited
+	var closers []io.Closer
+	defer func() {
+		for _, c := range closers {
+			c.Close()
+		}
+	}()
+
+	// Encodings are listed in the order they were applied
+	encodings := strings.Split(contentEncoding, ",")
+	for i := len(encodings) - 1; i >= 0; i-- {
+		decoder, err := newDecoder(strings.ToLower(strings.TrimSpace(encodings[i])), reader, limit)
+		if err != nil {
+			return nil, limitError(limited, err)
+		}
+		if c, ok := decoder.(io.Closer); ok {
+			closers = append(closers, c)
+		}
+		reader = decoder
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, limitError(limited, err)
+	}
+	if int64(len(data)) > limit {
+		return nil, ErrTooLarge
+	}
+	return data, nil
+}
+
+// limitError returns ErrTooLarge if the error was caused by the size of the body
+func limitError(limited *limitReader, err error) error {
+	if limited.exceeded || errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) {
+		return ErrTooLarge
+	}
+	return err
+}
+
+func newDecoder(encoding string, reader io.Reader, limit int64) (io.Reader, error) {
+	switch encoding {
+	case "", "identity":
+		return reader, nil
+	case "gzip", "x-gzip":
+		return gzip.NewReader(reader)
+	case "deflate":
+		return zlib.NewReader(reader)
+	case "br":
+		return brotli.NewReader(reader), nil
+	case "zstd":
+		// Streaming encoders declare the window of zstdMinWindow even for small bodies.
+		// Decoded size is limited by ReadAll anyway, the option bounds memory of the window.
+		maxMemory := uint64(limit) + 1
+		if maxMemory < zstdMinWindow {
+			maxMemory = zstdMinWindow
+		}
+		decoder, err := zstd.NewReader(reader, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(maxMemory))
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, encoding)
+}
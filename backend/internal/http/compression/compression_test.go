@@ -0,0 +1,128 @@
+package compression
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	gzip "github.com/klauspost/pgzip"
+)
+
+// encode compresses data with the encodings in the order of Content-Encoding header
+func encode(t *testing.T, data []byte, contentEncoding string) []byte {
+	t.Helper()
+	for _, encoding := range strings.Split(contentEncoding, ",") {
+		buf := &bytes.Buffer{}
+		var writer io.WriteCloser
+		switch strings.ToLower(strings.TrimSpace(encoding)) {
+		case "", "identity":
+			continue
+		case "gzip", "x-gzip":
+			writer = gzip.NewWriter(buf)
+		case "deflate":
+			writer = zlib.NewWriter(buf)
+		case "br":
+			writer = brotli.NewWriter(buf)
+		case "zstd":
+			var err error
+			if writer, err = zstd.NewWriter(buf); err != nil {
+				t.Fatal(err)
+			}
+		default:
+			t.Fatalf("unknown encoding: %s", encoding)
+		}
+		if _, err := writer.Write(data); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		data = buf.Bytes()
+	}
+	return data
+}
+
+func TestReadAll(t *testing.T) {
+	const limit = 1000
+	text := []byte(strings.Repeat("batch data ", 50))
+	tests := []struct {
+		name     string
+		encoding string
+		data     []byte
+	}{
+		{"no encoding", "", text},
+		{"identity", "identity", text},
+		{"gzip", "gzip", text},
+		{"x-gzip", "x-gzip", text},
+		{"deflate", "deflate", text},
+		{"br", "br", text},
+		{"zstd", "zstd", text},
+		{"case and spaces", " GZip ", text},
+		{"several encodings", "deflate, gzip", text},
+		{"exactly the limit", "gzip", bytes.Repeat([]byte{1}, limit)},
+		{"empty body", "gzip", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := encode(t, tt.data, tt.encoding)
+			data, err := ReadAll(bytes.NewReader(body), tt.encoding, limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, tt.data) {
+				t.Errorf("got %d bytes, want %d", len(data), len(tt.data))
+			}
+		})
+	}
+}
+
+func TestReadAllErrors(t *testing.T) {
+	const limit = 1000
+	large := bytes.Repeat([]byte{1}, limit+1)
+	// Compressed body is small, but the decompressed one is over the limit
+	bomb := bytes.Repeat([]byte{0}, 100*limit)
+	random := make([]byte, 2*limit)
+	for i := range random {
+		random[i] = byte(i*7919 + i/13)
+	}
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		err      error // nil for any error except the listed ones
+	}{
+		{"plain over the limit", "", large, ErrTooLarge},
+		{"gzip over the limit", "gzip", encode(t, large, "gzip"), ErrTooLarge},
+		{"gzip bomb", "gzip", encode(t, bomb, "gzip"), ErrTooLarge},
+		{"deflate bomb", "deflate", encode(t, bomb, "deflate"), ErrTooLarge},
+		{"br bomb", "br", encode(t, bomb, "br"), ErrTooLarge},
+		{"zstd bomb", "zstd", encode(t, bomb, "zstd"), ErrTooLarge},
+		{"nested bomb", "gzip, gzip", encode(t, bomb, "gzip, gzip"), ErrTooLarge},
+		{"compressed body over the limit", "gzip", encode(t, random, "gzip"), ErrTooLarge},
+		{"unsupported encoding", "compress", []byte("data"), ErrUnsupportedEncoding},
+		{"unsupported encoding in the list", "gzip, compress", []byte("data"), ErrUnsupportedEncoding},
+		{"not gzip", "gzip", []byte("plain text"), nil},
+		{"not deflate", "deflate", []byte("plain text"), nil},
+		{"not zstd", "zstd", []byte("plain text"), nil},
+		{"truncated gzip", "gzip", encode(t, []byte("batch data"), "gzip")[:10], nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadAll(bytes.NewReader(tt.body), tt.encoding, limit)
+			if err == nil {
+				t.Fatal("body is accepted")
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("got %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && (errors.Is(err, ErrTooLarge) || errors.Is(err, ErrUnsupportedEncoding)) {
+				t.Errorf("malformed body is reported as %v", err)
+			}
+		})
+	}
+}
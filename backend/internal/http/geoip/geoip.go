@@ -2,40 +2,100 @@ package geoip
 
 import (
 	"log"
+	"math"
 	"net"
 
 	maxminddb "github.com/oschwald/maxminddb-golang"
 )
 
+// Coordinates are rounded to 0.1 degree (~11km) for privacy and multiplied by this factor
+const CoordinatesFactor = 10
+
 type geoIPRecord struct {
 	Country struct {
 		ISOCode string `maxminddb:"iso_code"`
 	} `maxminddb:"country"`
+	Subdivisions []struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"subdivisions"`
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Location struct {
+		Latitude  float64 `maxminddb:"latitude"`
+		Longitude float64 `maxminddb:"longitude"`
+		TimeZone  string  `maxminddb:"time_zone"`
+	} `maxminddb:"location"`
+}
+
+type asnRecord struct {
+	Number uint64 `maxminddb:"autonomous_system_number"`
+	Org    string `maxminddb:"autonomous_system_organization"`
+}
+
+// GeoRecord contains location of the user. Empty fields mean unknown values.
+type GeoRecord struct {
+	Country   string
+	Region    string
+	City      string
+	Latitude  int64 // Multiplied by CoordinatesFactor
+	Longitude int64
+	TimeZone  string
+	ASN       uint64
+	ASNOrg    string
 }
 
 type GeoIP struct {
-	r *maxminddb.Reader
+	r   *maxminddb.Reader
+	asn *maxminddb.Reader // Optional
 }
 
-func NewGeoIP(file string) *GeoIP {
+func NewGeoIP(file string, asnFile string) *GeoIP {
 	r, err := maxminddb.Open(file)
 	if err != nil {
 		log.Fatalln(err)
 	}
-	return &GeoIP{r}
+	geoIP := &GeoIP{r: r}
+	if asnFile != "" {
+		if geoIP.asn, err = maxminddb.Open(asnFile); err != nil {
+			log.Fatalln(err)
+		}
+	}
+	return geoIP
 }
 
-func (geoIP *GeoIP) ExtractISOCode(ip net.IP) string {
+func (geoIP *GeoIP) Parse(ip net.IP) *GeoRecord {
+	res := &GeoRecord{Country: "UN"}
 	if ip == nil {
-		return "UN"
+		return res
 	}
-	var code string
 	var record geoIPRecord
 	if geoIP.r.Lookup(ip, &record) == nil {
-		code = record.Country.ISOCode
+		if record.Country.ISOCode != "" {
+			res.Country = record.Country.ISOCode
+		}
+		if len(record.Subdivisions) > 0 {
+			res.Region = record.Subdivisions[0].Names["en"]
+		}
+		res.City = record.City.Names["en"]
+		res.Latitude = roundCoordinate(record.Location.Latitude)
+		res.Longitude = roundCoordinate(record.Location.Longitude)
+		res.TimeZone = record.Location.TimeZone
 	}
-	if code == "" {
-		code = "UN"
+	if geoIP.asn != nil {
+		var asn asnRecord
+		if geoIP.asn.Lookup(ip, &asn) == nil {
+			res.ASN = asn.Number
+			res.ASNOrg = asn.Org
+		}
 	}
-	return code
+	return res
+}
+
+func (geoIP *GeoIP) ExtractISOCode(ip net.IP) string {
+	return geoIP.Parse(ip).Country
+}
+
+func roundCoordinate(c float64) int64 {
+	return int64(math.Round(c * CoordinatesFactor))
 }
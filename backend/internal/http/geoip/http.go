@@ -11,3 +11,8 @@ func (geoIP *GeoIP) ExtractISOCodeFromHTTPRequest(r *http.Request) string {
 	ip := net.ParseIP(realip.FromRequest(r))
 	return geoIP.ExtractISOCode(ip)
 }
+
+func (geoIP *GeoIP) ParseFromHTTPRequest(r *http.Request) *GeoRecord {
+	ip := net.ParseIP(realip.FromRequest(r))
+	return geoIP.Parse(ip)
+}
@@ -0,0 +1,18 @@
+package origin
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// FromHTTPRequest returns request origin from the Origin header or from the Referer if Origin is missing
+func FromHTTPRequest(r *http.Request) string {
+	if o := r.Header.Get("Origin"); o != "" && o != "null" {
+		return o
+	}
+	ref, err := url.Parse(r.Header.Get("Referer"))
+	if err != nil || ref.Host == "" {
+		return ""
+	}
+	return ref.Scheme + "://" + ref.Host
+}
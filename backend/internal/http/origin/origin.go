@@ -0,0 +1,58 @@
+package origin
+
+import (
+	"net/url"
+	"strings"
+)
+
+// IsAllowed checks origin against the list of allowed origins.
+// Allowlist entries may omit the scheme and may start with "*." to allow all subdomains.
+func IsAllowed(allowlist []string, origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range allowlist {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if i := strings.Index(allowed, "://"); i >= 0 {
+			if allowed[:i] != u.Scheme {
+				continue
+			}
+			allowed = allowed[i+3:]
+		}
+		allowed = strings.TrimSuffix(allowed, "/")
+		// Port is compared separately, so it works with wildcards too
+		if i := strings.LastIndex(allowed, ":"); i >= 0 && !strings.HasSuffix(allowed, "]") {
+			if allowed[i+1:] != port(u) {
+				continue
+			}
+			allowed = allowed[:i]
+		}
+		allowed = strings.Trim(allowed, "[]")
+		if strings.HasPrefix(allowed, "*.") {
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+			continue
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// port returns the port of the origin, the default one of the scheme if it isn't set
+func port(u *url.URL) string {
+	if p := u.Port(); p != "" {
+		return p
+	}
+	switch u.Scheme {
+	case "http":
+		return "80"
+	case "https":
+		return "443"
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package origin
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestIsAllowed(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist []string
+		origin    string
+		want      bool
+	}{
+		{"empty allowlist", nil, "https://example.com", false},
+		{"exact host", []string{"example.com"}, "https://example.com", true},
+		{"case and spaces", []string{" Example.COM/ "}, "https://EXAMPLE.com", true},
+		{"other host", []string{"example.com"}, "https://example.org", false},
+		{"host suffix", []string{"example.com"}, "https://badexample.com", false},
+		{"subdomain without wildcard", []string{"example.com"}, "https://app.example.com", false},
+		{"wildcard subdomain", []string{"*.example.com"}, "https://app.example.com", true},
+		{"wildcard nested subdomain", []string{"*.example.com"}, "https://a.b.example.com", true},
+		{"wildcard doesn't match the domain", []string{"*.example.com"}, "https://example.com", false},
+		{"wildcard suffix", []string{"*.example.com"}, "https://badexample.com", false},
+		{"scheme", []string{"https://example.com"}, "https://example.com", true},
+		{"other scheme", []string{"https://example.com"}, "http://example.com", false},
+		{"scheme with wildcard", []string{"https://*.example.com"}, "https://app.example.com", true},
+		{"any port without port", []string{"localhost"}, "http://localhost:3000", true},
+		{"port", []string{"localhost:3000"}, "http://localhost:3000", true},
+		{"other port", []string{"localhost:3000"}, "http://localhost:3001", false},
+		{"default port", []string{"example.com:443"}, "https://example.com", true},
+		{"default port of other scheme", []string{"example.com:80"}, "https://example.com", false},
+		{"port with wildcard", []string{"*.example.com:8443"}, "https://app.example.com:8443", true},
+		{"other port with wildcard", []string{"*.example.com:8443"}, "https://app.example.com", false},
+		{"scheme and port", []string{"http://localhost:8080"}, "http://localhost:8080", true},
+		{"IPv6", []string{"[::1]"}, "http://[::1]:8080", true},
+		{"IPv6 with port", []string{"[::1]:8080"}, "http://[::1]:8080", true},
+		{"IPv6 with other port", []string{"[::1]:8080"}, "http://[::1]:9090", false},
+		{"second entry", []string{"example.org", "example.com"}, "https://example.com", true},
+		{"empty origin", []string{"example.com"}, "", false},
+		{"origin without scheme", []string{"example.com"}, "example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAllowed(tt.allowlist, tt.origin); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromHTTPRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		origin  string
+		referer string
+		want    string
+	}{
+		{"origin", "https://example.com", "https://example.org/page", "https://example.com"},
+		{"referer", "", "https://example.org:8443/page?q=1", "https://example.org:8443"},
+		{"null origin", "null", "https://example.org/page", "https://example.org"},
+		{"nothing", "", "", ""},
+		{"relative referer", "", "/page", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := http.NewRequest(http.MethodPost, "http://localhost/v1/web/start", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.referer != "" {
+				r.Header.Set("Referer", tt.referer)
+			}
+			if got := FromHTTPRequest(r); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+
+	"openreplay/backend/internal/config/http"
+	"openreplay/backend/pkg/db/types"
+	"openreplay/backend/pkg/monitoring"
+	"openreplay/backend/pkg/redisstream"
+)
+
+// LimitError is returned when a project exceeds one of its ingest limits
+type LimitError struct {
+	Limit      string
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s limit has been reached", e.Limit)
+}
+
+const (
+	// storeErrorLogInterval limits logging of store errors, every request fails the same way while redis is down
+	storeErrorLogInterval = time.Minute
+	// storeTimeout is the deadline of a single redis call, requests wait for the limiter on every start and push
+	storeTimeout = 100 * time.Millisecond
+	// storeRetryInterval is the time the store isn't used after an error, then one request checks it again
+	storeRetryInterval = 10 * time.Second
+)
+
+// Limiter enforces per-project rate limits and monthly quotas.
+// Counters are kept in redis if it's configured, otherwise (or if redis is unavailable) in memory.
+// After a redis error only the in-memory counters are used for storeRetryInterval.
+// In-memory counters belong to the process: with N http instances the effective limits are N times higher,
+// and quotas counted in memory are lost on restart and aren't merged back to redis when it's available again.
+type Limiter struct {
+	cfg             *http.Config
+	store           Store
+	fallback        Store
+	storeDownUntil  int64 // unix ms until which the store isn't used, 0 if it's available
+	lastErrorLog    int64 // unix ms of the last logged store error
+	rejected        syncfloat64.Counter
+	startedSessions syncfloat64.Counter
+	pushedBytes     syncfloat64.Counter
+}
+
+func New(cfg *http.Config, metrics *monitoring.Metrics) *Limiter {
+	l := &Limiter{
+		cfg:      cfg,
+		fallback: NewMemoryStore(),
+	}
+	if client := redisstream.GetClient(); client != nil {
+		l.store = NewRedisStore(client, storeTimeout)
+	} else {
+		l.store = l.fallback
+	}
+	if metrics == nil {
+		return l
+	}
+	var err error
+	l.rejected, err = metrics.RegisterCounter("ratelimit_rejected")
+	if err != nil {
+		log.Printf("can't create ratelimit_rejected metric: %s", err)
+	}
+	l.startedSessions, err = metrics.RegisterCounter("ratelimit_sessions_started")
+	if err != nil {
+		log.Printf("can't create ratelimit_sessions_started metric: %s", err)
+	}
+	l.pushedBytes, err = metrics.RegisterCounter("ratelimit_bytes_pushed")
+	if err != nil {
+		log.Printf("can't create ratelimit_bytes_pushed metric: %s", err)
+	}
+	return l
+}
+
+// StartSession checks session start rate and monthly sessions quota of the project,
+// the quota is used only by SessionStarted, so concurrent starts might exceed it a little
+func (l *Limiter) StartSession(ctx context.Context, p *types.Project) *LimitError {
+	if l.cfg.SessionsRateLimit > 0 {
+		key := "ratelimit:sessions:" + strconv.FormatUint(uint64(p.ProjectID), 10)
+		if err := l.takeTokens(key, float64(l.cfg.SessionsRateLimit), float64(l.cfg.SessionsRateBurst), 1); err != nil {
+			l.countRejected(ctx, p.ProjectID, err)
+			return err
+		}
+	}
+	if quota := l.sessionsQuota(p); quota > 0 {
+		now := time.Now().UTC()
+		key, nextMonth := quotaKey(p, now)
+		if used := l.incr(key, 0, nextMonth.Add(24*time.Hour)); used >= quota {
+			err := &LimitError{Limit: "monthly sessions quota", RetryAfter: nextMonth.Sub(now)}
+			l.countRejected(ctx, p.ProjectID, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// SessionStarted counts the session in monthly quota of the project, it's called once the session is created
+func (l *Limiter) SessionStarted(ctx context.Context, p *types.Project) {
+	if quota := l.sessionsQuota(p); quota > 0 {
+		key, nextMonth := quotaKey(p, time.Now().UTC())
+		l.incr(key, 1, nextMonth.Add(24*time.Hour))
+	}
+	if l.startedSessions != nil {
+		l.startedSessions.Add(ctx, 1, attribute.Int64("project_id", int64(p.ProjectID)))
+	}
+}
+
+func (l *Limiter) sessionsQuota(p *types.Project) int64 {
+	if p.SessionsQuota != 0 {
+		return p.SessionsQuota
+	}
+	return l.cfg.MonthlySessionsQuota
+}
+
+// quotaKey returns the counter of the current month and the start of the next one
+func quotaKey(p *types.Project, now time.Time) (string, time.Time) {
+	nextMonth := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	return fmt.Sprintf("quota:sessions:%d:%s", p.ProjectID, now.Format("2006-01")), nextMonth
+}
+
+// PushBytes checks the rate of bytes sent by trackers of the project
+func (l *Limiter) PushBytes(ctx context.Context, projectID uint32, size int) *LimitError {
+	if l.cfg.BytesRateLimit > 0 {
+		// Batch bigger than bucket size would never pass otherwise
+		n := float64(size)
+		if burst := float64(l.cfg.BytesRateBurst); n > burst {
+			n = burst
+		}
+		key := "ratelimit:bytes:" + strconv.FormatUint(uint64(projectID), 10)
+		if err := l.takeTokens(key, float64(l.cfg.BytesRateLimit), float64(l.cfg.BytesRateBurst), n); err != nil {
+			l.countRejected(ctx, projectID, err)
+			return err
+		}
+	}
+	if l.pushedBytes != nil {
+		l.pushedBytes.Add(ctx, float64(size), attribute.Int64("project_id", int64(projectID)))
+	}
+	return nil
+}
+
+func (l *Limiter) takeTokens(key string, rate, burst, n float64) *LimitError {
+	store := l.currentStore()
+	wait, err := store.TakeTokens(key, rate, burst, n)
+	l.storeResult(store, err)
+	if err != nil {
+		l.logStoreError("can't take tokens from store, use in-memory fallback: %s", err)
+		wait, _ = l.fallback.TakeTokens(key, rate, burst, n)
+	}
+	if wait > 0 {
+		return &LimitError{Limit: "rate", RetryAfter: wait}
+	}
+	return nil
+}
+
+func (l *Limiter) incr(key string, delta int64, expireAt time.Time) int64 {
+	store := l.currentStore()
+	value, err := store.Incr(key, delta, expireAt)
+	l.storeResult(store, err)
+	if err != nil {
+		l.logStoreError("can't increase counter in store, use in-memory fallback: %s", err)
+		value, _ = l.fallback.Incr(key, delta, expireAt)
+	}
+	return value
+}
+
+// currentStore returns the fallback while the store is considered down, after storeRetryInterval
+// one caller checks the store again
+func (l *Limiter) currentStore() Store {
+	until := atomic.LoadInt64(&l.storeDownUntil)
+	if until == 0 {
+		return l.store
+	}
+	now := time.Now().UnixMilli()
+	if now >= until && atomic.CompareAndSwapInt64(&l.storeDownUntil, until, now+storeRetryInterval.Milliseconds()) {
+		return l.store
+	}
+	return l.fallback
+}
+
+// storeResult stops using the store after an error and resumes after a successful call
+func (l *Limiter) storeResult(store Store, err error) {
+	if store == l.fallback {
+		return
+	}
+	if err != nil {
+		atomic.StoreInt64(&l.storeDownUntil, time.Now().Add(storeRetryInterval).UnixMilli())
+		return
+	}
+	if atomic.SwapInt64(&l.storeDownUntil, 0) != 0 {
+		log.Printf("rate limit store is available again")
+	}
+}
+
+// logStoreError logs the error at most once per storeErrorLogInterval
+func (l *Limiter) logStoreError(format string, err error) {
+	now := time.Now().UnixMilli()
+	last := atomic.LoadInt64(&l.lastErrorLog)
+	if now-last < storeErrorLogInterval.Milliseconds() || !atomic.CompareAndSwapInt64(&l.lastErrorLog, last, now) {
+		return
+	}
+	log.Printf(format, err)
+}
+
+func (l *Limiter) countRejected(ctx context.Context, projectID uint32, err *LimitError) {
+	if l.rejected == nil {
+		return
+	}
+	l.rejected.Add(ctx, 1, attribute.Int64("project_id", int64(projectID)), attribute.String("limit", err.Limit))
+}
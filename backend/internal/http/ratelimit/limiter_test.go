@@ -0,0 +1,147 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"openreplay/backend/internal/config/http"
+	"openreplay/backend/pkg/db/types"
+)
+
+// testStore fails all the calls while broken is set
+type testStore struct {
+	Store
+	broken bool
+	calls  int
+}
+
+func (s *testStore) TakeTokens(key string, rate, burst, n float64) (time.Duration, error) {
+	s.calls++
+	if s.broken {
+		return 0, errors.New("store is down")
+	}
+	return s.Store.TakeTokens(key, rate, burst, n)
+}
+
+func (s *testStore) Incr(key string, delta int64, expireAt time.Time) (int64, error) {
+	s.calls++
+	if s.broken {
+		return 0, errors.New("store is down")
+	}
+	return s.Store.Incr(key, delta, expireAt)
+}
+
+func newTestLimiter(cfg *http.Config, store Store) *Limiter {
+	return &Limiter{cfg: cfg, store: store, fallback: NewMemoryStore()}
+}
+
+func TestMemoryStoreTakeTokens(t *testing.T) {
+	tests := []struct {
+		name  string
+		burst float64
+		taken []float64 // tokens taken one after another
+		wait  bool      // the last call has to wait
+	}{
+		{"within burst", 10, []float64{5, 5}, false},
+		{"over burst", 10, []float64{5, 5, 1}, true},
+		{"single large", 10, []float64{11}, true},
+		{"different sizes", 100, []float64{1, 10, 50, 39}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			var wait time.Duration
+			for _, n := range tt.taken {
+				wait, _ = store.TakeTokens("key", 0.001, tt.burst, n)
+			}
+			if (wait > 0) != tt.wait {
+				t.Errorf("got wait %s, want wait %t", wait, tt.wait)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreRefill(t *testing.T) {
+	store := NewMemoryStore()
+	if wait, _ := store.TakeTokens("key", 1000, 10, 10); wait != 0 {
+		t.Fatal("full bucket is empty")
+	}
+	wait, _ := store.TakeTokens("key", 1000, 10, 10)
+	if wait <= 0 || wait > 10*time.Millisecond {
+		t.Fatalf("got wait %s, want up to 10ms", wait)
+	}
+	time.Sleep(wait)
+	if wait, _ := store.TakeTokens("key", 1000, 10, 10); wait != 0 {
+		t.Errorf("bucket isn't refilled, wait %s", wait)
+	}
+}
+
+func TestMemoryStoreIncr(t *testing.T) {
+	store := NewMemoryStore()
+	expireAt := time.Now().Add(time.Hour)
+	for i := int64(1); i <= 3; i++ {
+		if value, _ := store.Incr("key", 1, expireAt); value != i {
+			t.Errorf("got %d, want %d", value, i)
+		}
+	}
+	if value, _ := store.Incr("key", 0, expireAt); value != 3 {
+		t.Errorf("zero delta changed the counter to %d", value)
+	}
+	// Expired counter starts again
+	if value, _ := store.Incr("expired", 5, time.Now().Add(-time.Second)); value != 5 {
+		t.Fatalf("got %d, want 5", value)
+	}
+	if value, _ := store.Incr("expired", 1, time.Now().Add(time.Hour)); value != 1 {
+		t.Errorf("expired counter isn't reset, got %d", value)
+	}
+}
+
+func TestLimiterFallback(t *testing.T) {
+	cfg := &http.Config{SessionsRateLimit: 1, SessionsRateBurst: 2}
+	store := &testStore{Store: NewMemoryStore(), broken: true}
+	l := newTestLimiter(cfg, store)
+	p := &types.Project{ProjectID: 1}
+	ctx := context.Background()
+	// Limits are still enforced by the in-memory counters
+	for i := 0; i < 2; i++ {
+		if err := l.StartSession(ctx, p); err != nil {
+			t.Fatalf("session %d is rejected: %s", i, err)
+		}
+	}
+	if err := l.StartSession(ctx, p); err == nil {
+		t.Error("session over the burst isn't rejected")
+	}
+	// Store isn't called after the first error
+	if store.calls != 1 {
+		t.Errorf("store is called %d times, want 1", store.calls)
+	}
+
+	// After the retry interval the store is checked again and used once it works
+	store.broken = false
+	l.storeDownUntil = time.Now().Add(-time.Second).UnixMilli()
+	if err := l.StartSession(ctx, &types.Project{ProjectID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if store.calls != 2 || l.storeDownUntil != 0 {
+		t.Errorf("store isn't used again, calls: %d", store.calls)
+	}
+}
+
+func TestLimiterQuotaFallback(t *testing.T) {
+	cfg := &http.Config{MonthlySessionsQuota: 2}
+	l := newTestLimiter(cfg, &testStore{Store: NewMemoryStore(), broken: true})
+	p := &types.Project{ProjectID: 1}
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := l.StartSession(ctx, p); err != nil {
+			t.Fatalf("session %d is rejected: %s", i, err)
+		}
+		l.SessionStarted(ctx, p)
+	}
+	err := l.StartSession(ctx, p)
+	if err == nil || err.RetryAfter <= 0 {
+		t.Errorf("session over the quota isn't rejected: %v", err)
+	}
+}
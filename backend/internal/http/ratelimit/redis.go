@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// Returns time to wait in ms, 0 if tokens were taken
+var takeTokensScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local now = tonumber(ARGV[4])
+local b = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(b[1]) or burst
+local ts = tonumber(b[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+local wait = 0
+if tokens >= n then
+	tokens = tokens - n
+else
+	wait = math.ceil((n - tokens) * 1000 / rate)
+end
+redis.call('HMSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return wait
+`)
+
+type redisStore struct {
+	redis *redis.Client
+}
+
+// NewRedisStore returns the store with its own connections to redis, which fail calls longer than timeout
+func NewRedisStore(client *redis.Client, timeout time.Duration) Store {
+	opts := *client.Options()
+	opts.DialTimeout = timeout
+	opts.ReadTimeout = timeout
+	opts.WriteTimeout = timeout
+	opts.MaxRetries = 0
+	return &redisStore{redis: redis.NewClient(&opts)}
+}
+
+func (s *redisStore) TakeTokens(key string, rate, burst, n float64) (time.Duration, error) {
+	wait, err := takeTokensScript.Run(s.redis, []string{key}, rate, burst, n, time.Now().UnixMilli()).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+func (s *redisStore) Incr(key string, delta int64, expireAt time.Time) (int64, error) {
+	pipe := s.redis.TxPipeline()
+	incr := pipe.IncrBy(key, delta)
+	pipe.ExpireAt(key, expireAt)
+	if _, err := pipe.Exec(); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
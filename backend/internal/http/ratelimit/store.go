@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Store keeps token buckets and counters shared between http service instances
+type Store interface {
+	// TakeTokens removes n tokens from the bucket and returns 0 on success or time to wait until enough tokens
+	TakeTokens(key string, rate, burst, n float64) (time.Duration, error)
+	// Incr increases the counter and returns its new value
+	Incr(key string, delta int64, expireAt time.Time) (int64, error)
+}
+
+type bucket struct {
+	tokens float64
+	ts     time.Time
+}
+
+type counter struct {
+	value    int64
+	expireAt time.Time
+}
+
+// memoryStore is an in-process fallback for deployments without redis, its counters aren't shared between instances
+type memoryStore struct {
+	mutex    sync.Mutex
+	buckets  map[string]*bucket
+	counters map[string]*counter
+}
+
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets:  make(map[string]*bucket),
+		counters: make(map[string]*counter),
+	}
+}
+
+func (s *memoryStore) TakeTokens(key string, rate, burst, n float64) (time.Duration, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, ts: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.ts).Seconds()*rate)
+	b.ts = now
+	if b.tokens >= n {
+		b.tokens -= n
+		return 0, nil
+	}
+	return time.Duration(math.Ceil((n-b.tokens)/rate*1000)) * time.Millisecond, nil
+}
+
+func (s *memoryStore) Incr(key string, delta int64, expireAt time.Time) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok || time.Now().After(c.expireAt) {
+		c = &counter{expireAt: expireAt}
+		s.counters[key] = c
+	}
+	c.value += delta
+	return c.value, nil
+}
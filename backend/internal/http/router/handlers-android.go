@@ -0,0 +1,126 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"openreplay/backend/internal/http/android"
+	"openreplay/backend/internal/http/sampler"
+	"openreplay/backend/internal/http/uuid"
+	"strconv"
+	"time"
+
+	"openreplay/backend/pkg/db/postgres"
+	. "openreplay/backend/pkg/messages"
+	"openreplay/backend/pkg/token"
+)
+
+func (e *Router) startSessionHandlerAndroid(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+	req := &StartAndroidSessionRequest{}
+
+	if r.Body == nil {
+		ResponseWithError(w, http.StatusBadRequest, errors.New("request body is empty"))
+		return
+	}
+	body := http.MaxBytesReader(w, r.Body, e.cfg.JsonSizeLimit)
+	defer body.Close()
+
+	if err := json.NewDecoder(body).Decode(req); err != nil {
+		ResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.ProjectKey == nil {
+		ResponseWithError(w, http.StatusForbidden, errors.New("ProjectKey value required"))
+		return
+	}
+
+	p, err := e.services.Database.GetProjectByKey(*req.ProjectKey)
+	if err != nil {
+		if postgres.IsNoRowsErr(err) {
+			ResponseWithError(w, http.StatusNotFound, errors.New("Project doesn't exist or is not active"))
+		} else {
+			ResponseWithError(w, http.StatusInternalServerError, err) // TODO: send error here only on staging
+		}
+		return
+	}
+	userUUID := uuid.GetUUID(req.UserUUID)
+	tokenData, err := e.services.Tokenizer.Parse(req.Token)
+	var previousSessionID uint64
+	if err != nil { // Starting the new one
+		previousSessionID = expiredSessionID(tokenData, err, p)
+		geo := e.services.GeoIP.ParseFromHTTPRequest(r)
+		if !sampler.IsSampled(p, &sampler.Session{
+			UserUUID:       userUUID,
+			Country:        geo.Country,
+			TrackerVersion: req.TrackerVersion,
+		}) {
+			ResponseWithError(w, http.StatusForbidden, errors.New("cancel"))
+			return
+		}
+
+		if limitErr := e.services.Limiter.StartSession(r.Context(), p); limitErr != nil {
+			ResponseWithRetryAfter(w, http.StatusTooManyRequests, limitErr.RetryAfter, limitErr)
+			return
+		}
+		sessionID, err := e.services.Flaker.Compose(uint64(startTime.UnixMilli()))
+		if err != nil {
+			ResponseWithError(w, http.StatusInternalServerError, err)
+			return
+		}
+		expTime := startTime.Add(time.Duration(p.MaxSessionDuration) * time.Millisecond)
+		tokenData = &token.TokenData{ID: sessionID, ExpTime: expTime.UnixMilli(), ProjectID: p.ProjectID}
+
+		deviceType := req.UserDeviceType
+		if deviceType == "" {
+			deviceType = android.GetAndroidDeviceType(req.UserDevice)
+		}
+		e.services.Producer.Produce(e.cfg.TopicRawAndroid, tokenData.ID, Encode(&AndroidSessionStart{
+			Timestamp:      req.Timestamp,
+			ProjectID:      uint64(p.ProjectID),
+			TrackerVersion: req.TrackerVersion,
+			RevID:          req.RevID,
+			UserUUID:       userUUID,
+			UserOS:         "Android",
+			UserOSVersion:  req.UserOSVersion,
+			UserDevice:     android.MapAndroidDevice(req.UserDevice),
+			UserDeviceType: deviceType,
+			UserCountry:    geo.Country,
+		}))
+		e.sendSessionMessage(e.cfg.TopicRawAndroid, sessionID, sessionGeo(geo))
+		if previousSessionID != 0 {
+			e.sendSessionAssociation(e.cfg.TopicRawAndroid, sessionID, previousSessionID, req.Timestamp)
+		}
+		e.services.Limiter.SessionStarted(r.Context(), p)
+	}
+
+	ResponseWithJSON(w, &StartAndroidSessionResponse{
+		Token:             e.services.Tokenizer.Compose(*tokenData),
+		ImagesHashList:    e.services.Images.HashList(uint64(p.ProjectID)),
+		UserUUID:          userUUID,
+		SessionID:         strconv.FormatUint(tokenData.ID, 10),
+		BeaconSizeLimit:   e.cfg.BeaconSizeLimit,
+		PreviousSessionID: formatSessionID(previousSessionID),
+		CaptureSettings:   p.CaptureSettings,
+	})
+}
+
+func (e *Router) pushMessagesHandlerAndroid(w http.ResponseWriter, r *http.Request) {
+	sessionData, err := e.services.Tokenizer.ParseFromHTTPRequest(r)
+	if err != nil {
+		ResponseWithError(w, http.StatusUnauthorized, err)
+		return
+	}
+	e.pushMessages(w, r, sessionData, e.cfg.TopicRawAndroid)
+}
+
+func (e *Router) pushLateMessagesHandlerAndroid(w http.ResponseWriter, r *http.Request) {
+	sessionData, err := e.services.Tokenizer.ParseFromHTTPRequest(r)
+	if err != nil && err != token.EXPIRED {
+		ResponseWithError(w, http.StatusUnauthorized, err)
+		return
+	}
+	// Check timestamps here?
+	e.pushMessages(w, r, sessionData, e.cfg.TopicRawAndroid)
+}
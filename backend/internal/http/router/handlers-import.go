@@ -0,0 +1,116 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"openreplay/backend/internal/http/uaparser"
+	"openreplay/backend/internal/http/uuid"
+	. "openreplay/backend/pkg/messages"
+	"openreplay/backend/pkg/rrweb"
+)
+
+// Tracker version of imported sessions
+const importTrackerVersion = "rrweb"
+
+// importHandlerWeb creates a session from rrweb recording, it goes through the same queue as the tracker's one
+func (e *Router) importHandlerWeb(w http.ResponseWriter, r *http.Request) {
+	p := e.authorizeBySecretKey(w, r)
+	if p == nil {
+		return
+	}
+	if r.Body == nil {
+		ResponseWithError(w, http.StatusBadRequest, errors.New("request body is empty"))
+		return
+	}
+	bodyBytes, err := e.readBody(w, r, e.cfg.ImportSizeLimit)
+	if err != nil {
+		log.Printf("error while reading request body: %s", err)
+		ResponseWithError(w, bodyErrorStatus(err), err)
+		return
+	}
+	req := &ImportSessionRequest{}
+	if err := json.Unmarshal(bodyBytes, req); err != nil {
+		ResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+	if len(req.Events) == 0 {
+		ResponseWithError(w, http.StatusBadRequest, errors.New("recording is empty"))
+		return
+	}
+	msgs, err := rrweb.Convert(req.Events)
+	if err != nil {
+		ResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	startTs := req.Events[0].Timestamp
+	for _, ev := range req.Events {
+		if ev.Timestamp < startTs {
+			startTs = ev.Timestamp
+		}
+	}
+	sessionID, err := e.services.Flaker.Compose(uint64(startTs))
+	if err != nil {
+		ResponseWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+	ua := e.services.UaParser.Parse(req.UserAgent)
+	if ua == nil {
+		ua = &uaparser.UA{OS: "Other", Browser: "Other", DeviceType: "other"}
+	}
+	country := req.UserCountry
+	if country == "" {
+		country = "UN"
+	}
+	trackerVersion := req.TrackerVersion
+	if trackerVersion == "" {
+		trackerVersion = importTrackerVersion
+	}
+	sessionStart := &SessionStart{
+		Timestamp:          uint64(startTs),
+		ProjectID:          uint64(p.ProjectID),
+		TrackerVersion:     trackerVersion,
+		RevID:              req.RevID,
+		UserUUID:           uuid.GetUUID(req.UserUUID),
+		UserAgent:          req.UserAgent,
+		UserOS:             ua.OS,
+		UserOSVersion:      ua.OSVersion,
+		UserBrowser:        ua.Browser,
+		UserBrowserVersion: ua.BrowserVersion,
+		UserDevice:         ua.Device,
+		UserDeviceType:     ua.DeviceType,
+		UserCountry:        country,
+		UserID:             req.UserID,
+	}
+	if err := e.services.Database.InsertWebSessionStart(sessionID, sessionStart); err != nil {
+		log.Printf("can't insert imported session start: %s", err)
+	}
+	if err := e.services.Producer.Produce(e.cfg.TopicRawWeb, sessionID, Encode(sessionStart)); err != nil {
+		log.Printf("can't send imported session start: %s", err)
+		ResponseWithRetryAfter(w, http.StatusServiceUnavailable, e.cfg.SpoolRetryAfter, err)
+		return
+	}
+
+	head := []Message{&Timestamp{Timestamp: uint64(startTs)}}
+	for key, value := range req.Metadata {
+		head = append(head, &Metadata{Key: key, Value: value})
+	}
+	for _, batch := range EncodeBatches(append(head, msgs...), 1, int(e.cfg.BeaconSizeLimit)) {
+		batch, err := e.services.Scrubber.ScrubBatch(r.Context(), p, batch)
+		if err != nil {
+			log.Printf("can't scrub imported messages, sessID: %d, err: %s", sessionID, err)
+			ResponseWithError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if err := e.services.Producer.Produce(e.cfg.TopicRawWeb, sessionID, batch); err != nil {
+			log.Printf("can't send imported messages, sessID: %d, err: %s", sessionID, err)
+			ResponseWithRetryAfter(w, http.StatusServiceUnavailable, e.cfg.SpoolRetryAfter, err)
+			return
+		}
+	}
+	ResponseWithJSON(w, &ImportSessionResponse{SessionID: strconv.FormatUint(sessionID, 10)})
+}
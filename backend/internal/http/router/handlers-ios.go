@@ -3,11 +3,9 @@ package router
 import (
 	"encoding/json"
 	"errors"
-	"log"
-	"math/rand"
 	"net/http"
 	"openreplay/backend/internal/http/ios"
-	"openreplay/backend/internal/http/util"
+	"openreplay/backend/internal/http/sampler"
 	"openreplay/backend/internal/http/uuid"
 	"strconv"
 	"time"
@@ -49,10 +47,15 @@ func (e *Router) startSessionHandlerIOS(w http.ResponseWriter, r *http.Request)
 	}
 	userUUID := uuid.GetUUID(req.UserUUID)
 	tokenData, err := e.services.Tokenizer.Parse(req.Token)
-
+	var previousSessionID uint64
 	if err != nil { // Starting the new one
-		dice := byte(rand.Intn(100)) // [0, 100)
-		if dice >= p.SampleRate {
+		previousSessionID = expiredSessionID(tokenData, err, p)
+		geo := e.services.GeoIP.ParseFromHTTPRequest(r)
+		if !sampler.IsSampled(p, &sampler.Session{
+			UserUUID:       userUUID,
+			Country:        geo.Country,
+			TrackerVersion: req.TrackerVersion,
+		}) {
 			ResponseWithError(w, http.StatusForbidden, errors.New("cancel"))
 			return
 		}
@@ -62,16 +65,17 @@ func (e *Router) startSessionHandlerIOS(w http.ResponseWriter, r *http.Request)
 			ResponseWithError(w, http.StatusForbidden, errors.New("browser not recognized"))
 			return
 		}
+		if limitErr := e.services.Limiter.StartSession(r.Context(), p); limitErr != nil {
+			ResponseWithRetryAfter(w, http.StatusTooManyRequests, limitErr.RetryAfter, limitErr)
+			return
+		}
 		sessionID, err := e.services.Flaker.Compose(uint64(startTime.UnixMilli()))
 		if err != nil {
 			ResponseWithError(w, http.StatusInternalServerError, err)
 			return
 		}
-		// TODO: if EXPIRED => send message for two sessions association
 		expTime := startTime.Add(time.Duration(p.MaxSessionDuration) * time.Millisecond)
-		tokenData = &token.TokenData{sessionID, expTime.UnixMilli()}
-
-		country := e.services.GeoIP.ExtractISOCodeFromHTTPRequest(r)
+		tokenData = &token.TokenData{ID: sessionID, ExpTime: expTime.UnixMilli(), ProjectID: p.ProjectID}
 
 		// The difference with web is mostly here:
 		e.services.Producer.Produce(e.cfg.TopicRawIOS, tokenData.ID, Encode(&IOSSessionStart{
@@ -84,15 +88,23 @@ func (e *Router) startSessionHandlerIOS(w http.ResponseWriter, r *http.Request)
 			UserOSVersion:  req.UserOSVersion,
 			UserDevice:     ios.MapIOSDevice(req.UserDevice),
 			UserDeviceType: ios.GetIOSDeviceType(req.UserDevice),
-			UserCountry:    country,
+			UserCountry:    geo.Country,
 		}))
+		e.sendSessionMessage(e.cfg.TopicRawIOS, sessionID, sessionGeo(geo))
+		if previousSessionID != 0 {
+			e.sendSessionAssociation(e.cfg.TopicRawIOS, sessionID, previousSessionID, req.Timestamp)
+		}
+		e.services.Limiter.SessionStarted(r.Context(), p)
 	}
 
 	ResponseWithJSON(w, &StartIOSSessionResponse{
-		Token:           e.services.Tokenizer.Compose(*tokenData),
-		UserUUID:        userUUID,
-		SessionID:       strconv.FormatUint(tokenData.ID, 10),
-		BeaconSizeLimit: e.cfg.BeaconSizeLimit,
+		Token:             e.services.Tokenizer.Compose(*tokenData),
+		ImagesHashList:    e.services.Images.HashList(uint64(p.ProjectID)),
+		UserUUID:          userUUID,
+		SessionID:         strconv.FormatUint(tokenData.ID, 10),
+		BeaconSizeLimit:   e.cfg.BeaconSizeLimit,
+		PreviousSessionID: formatSessionID(previousSessionID),
+		CaptureSettings:   p.CaptureSettings,
 	})
 }
 
@@ -102,7 +114,7 @@ func (e *Router) pushMessagesHandlerIOS(w http.ResponseWriter, r *http.Request)
 		ResponseWithError(w, http.StatusUnauthorized, err)
 		return
 	}
-	e.pushMessages(w, r, sessionData.ID, e.cfg.TopicRawIOS)
+	e.pushMessages(w, r, sessionData, e.cfg.TopicRawIOS)
 }
 
 func (e *Router) pushLateMessagesHandlerIOS(w http.ResponseWriter, r *http.Request) {
@@ -112,62 +124,5 @@ func (e *Router) pushLateMessagesHandlerIOS(w http.ResponseWriter, r *http.Reque
 		return
 	}
 	// Check timestamps here?
-	e.pushMessages(w, r, sessionData.ID, e.cfg.TopicRawIOS)
-}
-
-func (e *Router) imagesUploadHandlerIOS(w http.ResponseWriter, r *http.Request) {
-	log.Printf("recieved imagerequest")
-
-	sessionData, err := e.services.Tokenizer.ParseFromHTTPRequest(r)
-	if err != nil { // Should accept expired token?
-		ResponseWithError(w, http.StatusUnauthorized, err)
-		return
-	}
-
-	if r.Body == nil {
-		ResponseWithError(w, http.StatusBadRequest, errors.New("request body is empty"))
-		return
-	}
-	r.Body = http.MaxBytesReader(w, r.Body, e.cfg.FileSizeLimit)
-	defer r.Body.Close()
-
-	err = r.ParseMultipartForm(1e6) // ~1Mb
-	if err == http.ErrNotMultipart || err == http.ErrMissingBoundary {
-		ResponseWithError(w, http.StatusUnsupportedMediaType, err)
-		return
-		// } else if err == multipart.ErrMessageTooLarge // if non-files part exceeds 10 MB
-	} else if err != nil {
-		ResponseWithError(w, http.StatusInternalServerError, err) // TODO: send error here only on staging
-		return
-	}
-
-	if r.MultipartForm == nil {
-		ResponseWithError(w, http.StatusInternalServerError, errors.New("Multipart not parsed"))
-		return
-	}
-
-	if len(r.MultipartForm.Value["projectKey"]) == 0 {
-		ResponseWithError(w, http.StatusBadRequest, errors.New("projectKey parameter missing")) // status for missing/wrong parameter?
-		return
-	}
-
-	prefix := r.MultipartForm.Value["projectKey"][0] + "/" + strconv.FormatUint(sessionData.ID, 10) + "/"
-
-	for _, fileHeaderList := range r.MultipartForm.File {
-		for _, fileHeader := range fileHeaderList {
-			file, err := fileHeader.Open()
-			if err != nil {
-				continue // TODO: send server error or accumulate successful files
-			}
-			key := prefix + fileHeader.Filename
-			log.Printf("Uploading image... %v", util.SafeString(key))
-			go func() { //TODO: mime type from header
-				if err := e.services.Storage.Upload(file, key, "image/jpeg", false); err != nil {
-					log.Printf("Upload ios screen error. %v", err)
-				}
-			}()
-		}
-	}
-
-	w.WriteHeader(http.StatusOK)
+	e.pushMessages(w, r, sessionData, e.cfg.TopicRawIOS)
 }
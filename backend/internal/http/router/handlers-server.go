@@ -0,0 +1,129 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"openreplay/backend/pkg/db/postgres"
+	"openreplay/backend/pkg/db/types"
+	. "openreplay/backend/pkg/messages"
+)
+
+// authorizeBySecretKey returns the project of the secret key or writes an error response and returns nil
+func (e *Router) authorizeBySecretKey(w http.ResponseWriter, r *http.Request) *types.Project {
+	secretKey := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if secretKey == "" {
+		ResponseWithError(w, http.StatusUnauthorized, errors.New("Missing secret key"))
+		return nil
+	}
+	p, err := e.services.Database.GetProjectBySecretKey(secretKey)
+	if err != nil {
+		if postgres.IsNoRowsErr(err) {
+			ResponseWithError(w, http.StatusUnauthorized, errors.New("Wrong secret key"))
+		} else {
+			ResponseWithError(w, http.StatusInternalServerError, err) // TODO: send error here only on staging
+		}
+		return nil
+	}
+	return p
+}
+
+// eventsHandlerServer accepts events of the customer's backend, it's authorized by the project secret key
+func (e *Router) eventsHandlerServer(w http.ResponseWriter, r *http.Request) {
+	p := e.authorizeBySecretKey(w, r)
+	if p == nil {
+		return
+	}
+
+	if r.Body == nil {
+		ResponseWithError(w, http.StatusBadRequest, errors.New("request body is empty"))
+		return
+	}
+	bodyBytes, err := e.readBody(w, r, e.cfg.BeaconSizeLimit)
+	if err != nil {
+		log.Printf("error while reading request body: %s", err)
+		ResponseWithError(w, bodyErrorStatus(err), err)
+		return
+	}
+	req := &ServerEventsRequest{}
+	if err := json.Unmarshal(bodyBytes, req); err != nil {
+		ResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	sessionID, err := strconv.ParseUint(req.SessionID, 10, 64)
+	if err != nil {
+		ResponseWithError(w, http.StatusBadRequest, errors.New("wrong sessionID"))
+		return
+	}
+	projectID, err := e.services.Database.GetSessionProjectID(sessionID)
+	if err != nil {
+		if postgres.IsNoRowsErr(err) {
+			ResponseWithError(w, http.StatusNotFound, errors.New("Session doesn't exist"))
+		} else {
+			ResponseWithError(w, http.StatusInternalServerError, err) // TODO: send error here only on staging
+		}
+		return
+	}
+	if projectID != p.ProjectID {
+		ResponseWithError(w, http.StatusForbidden, errors.New("Session belongs to another project"))
+		return
+	}
+
+	batch := encodeServerBatch(req, time.Now())
+	if limitErr := e.services.Limiter.PushBytes(r.Context(), p.ProjectID, len(batch)); limitErr != nil {
+		ResponseWithRetryAfter(w, http.StatusTooManyRequests, limitErr.RetryAfter, limitErr)
+		return
+	}
+	if batch, err = e.services.Scrubber.ScrubBatch(r.Context(), p, batch); err != nil {
+		log.Printf("can't scrub server events, sessID: %d, err: %s", sessionID, err)
+		ResponseWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := e.services.Producer.Produce(e.cfg.TopicRawWeb, sessionID, batch); err != nil {
+		log.Printf("can't send server events to queue: %s", err)
+		ResponseWithRetryAfter(w, http.StatusServiceUnavailable, e.cfg.SpoolRetryAfter, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// encodeServerBatch composes the batch in the same format as tracker does, every message is preceded by its timestamp
+func encodeServerBatch(req *ServerEventsRequest, now time.Time) []byte {
+	nowTs := now.UnixMilli()
+	timestamp := func(ts int64) *Timestamp {
+		if ts <= 0 {
+			ts = nowTs
+		}
+		return &Timestamp{Timestamp: uint64(ts)}
+	}
+	buf := bytes.NewBuffer(Encode(&BatchMeta{
+		PageNo:     ServerPageNo,
+		FirstIndex: uint64(nowTs) & 0xFFFFFFFF,
+		Timestamp:  nowTs,
+	}))
+	write := func(ts int64, msg Message) {
+		buf.Write(Encode(timestamp(ts)))
+		buf.Write(Encode(msg))
+	}
+	if req.UserID != "" {
+		write(0, &UserID{ID: req.UserID})
+	}
+	for _, m := range req.Metadata {
+		write(m.Timestamp, &Metadata{Key: m.Key, Value: m.Value})
+	}
+	for _, ev := range req.Events {
+		write(ev.Timestamp, &RawCustomEvent{Name: ev.Name, Payload: string(ev.Payload)})
+	}
+	for _, issue := range req.Issues {
+		write(issue.Timestamp, &CustomIssue{Name: issue.Name, Payload: string(issue.Payload)})
+	}
+	return buf.Bytes()
+}
@@ -4,10 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"go.opentelemetry.io/otel/attribute"
-	"io"
 	"log"
-	"math/rand"
 	"net/http"
+	"openreplay/backend/internal/http/compression"
+	"openreplay/backend/internal/http/sampler"
+	"openreplay/backend/internal/http/uaparser"
 	"openreplay/backend/internal/http/uuid"
 	"openreplay/backend/pkg/flakeid"
 	"strconv"
@@ -19,8 +20,9 @@ import (
 )
 
 func (e *Router) readBody(w http.ResponseWriter, r *http.Request, limit int64) ([]byte, error) {
-	body := http.MaxBytesReader(w, r.Body, limit)
-	bodyBytes, err := io.ReadAll(body)
+	// One byte more than the limit, so compression.ReadAll detects the large body itself
+	body := http.MaxBytesReader(w, r.Body, limit+1)
+	bodyBytes, err := compression.ReadAll(body, r.Header.Get("Content-Encoding"), limit)
 	if closeErr := body.Close(); closeErr != nil {
 		log.Printf("error while closing request body: %s", closeErr)
 	}
@@ -37,9 +39,37 @@ func (e *Router) readBody(w http.ResponseWriter, r *http.Request, limit int64) (
 	return bodyBytes, nil
 }
 
+// countRejectedBatch counts rejections by project of the token, it's 0 for legacy tokens without project
+func (e *Router) countRejectedBatch(r *http.Request, sessionData *token.TokenData, reason string) {
+	e.rejectedBatches.Add(
+		r.Context(),
+		1,
+		[]attribute.KeyValue{attribute.Int64("project_id", int64(sessionData.ProjectID)), attribute.String("reason", reason)}...,
+	)
+}
+
+// minClockSkew is the smallest difference between clocks (ms) we correct, smaller ones are hidden by network latency
+const minClockSkew = 1000
+
+// clockSkew estimates how far the user's clock is behind the server's one (ms).
+// Only web sessions are corrected, iOS and Android timestamps are stored as they come from the device.
+func clockSkew(serverTime time.Time, userTimestamp uint64) int64 {
+	if userTimestamp == 0 {
+		return 0
+	}
+	skew := serverTime.UnixMilli() - int64(userTimestamp)
+	if skew > -minClockSkew && skew < minClockSkew {
+		return 0
+	}
+	return skew
+}
+
 func (e *Router) startSessionHandlerWeb(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 
+	// Ask browser for high entropy client hints on the next starts
+	w.Header().Set("Accept-CH", uaparser.AcceptCH)
+
 	// Check request body
 	if r.Body == nil {
 		ResponseWithError(w, http.StatusBadRequest, errors.New("request body is empty"))
@@ -49,7 +79,7 @@ func (e *Router) startSessionHandlerWeb(w http.ResponseWriter, r *http.Request)
 	bodyBytes, err := e.readBody(w, r, e.cfg.JsonSizeLimit)
 	if err != nil {
 		log.Printf("error while reading request body: %s", err)
-		ResponseWithError(w, http.StatusRequestEntityTooLarge, err)
+		ResponseWithError(w, bodyErrorStatus(err), err)
 		return
 	}
 
@@ -77,11 +107,30 @@ func (e *Router) startSessionHandlerWeb(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if !e.checkOrigin(w, r, p) {
+		ResponseWithError(w, http.StatusForbidden, errors.New("origin is not allowed"))
+		return
+	}
+
 	userUUID := uuid.GetUUID(req.UserUUID)
 	tokenData, err := e.services.Tokenizer.Parse(req.Token)
+	var previousSessionID uint64
 	if err != nil || req.Reset { // Starting the new one
-		dice := byte(rand.Intn(100)) // [0, 100)
-		if dice >= p.SampleRate {
+		if !req.Reset {
+			previousSessionID = expiredSessionID(tokenData, err, p)
+		}
+		geo := e.services.GeoIP.ParseFromHTTPRequest(r)
+		pageURL := req.URL
+		if pageURL == "" {
+			pageURL = r.Header.Get("Referer")
+		}
+		if !sampler.IsSampled(p, &sampler.Session{
+			UserUUID:       userUUID,
+			UserID:         req.UserID,
+			Country:        geo.Country,
+			URL:            pageURL,
+			TrackerVersion: req.TrackerVersion,
+		}) {
 			ResponseWithError(w, http.StatusForbidden, errors.New("cancel"))
 			return
 		}
@@ -91,14 +140,17 @@ func (e *Router) startSessionHandlerWeb(w http.ResponseWriter, r *http.Request)
 			ResponseWithError(w, http.StatusForbidden, errors.New("browser not recognized"))
 			return
 		}
+		if limitErr := e.services.Limiter.StartSession(r.Context(), p); limitErr != nil {
+			ResponseWithRetryAfter(w, http.StatusTooManyRequests, limitErr.RetryAfter, limitErr)
+			return
+		}
 		sessionID, err := e.services.Flaker.Compose(uint64(startTime.UnixMilli()))
 		if err != nil {
 			ResponseWithError(w, http.StatusInternalServerError, err)
 			return
 		}
-		// TODO: if EXPIRED => send message for two sessions association
 		expTime := startTime.Add(time.Duration(p.MaxSessionDuration) * time.Millisecond)
-		tokenData = &token.TokenData{ID: sessionID, ExpTime: expTime.UnixMilli()}
+		tokenData = &token.TokenData{ID: sessionID, ExpTime: expTime.UnixMilli(), ProjectID: p.ProjectID}
 
 		sessionStart := &SessionStart{
 			Timestamp:            req.Timestamp,
@@ -113,7 +165,7 @@ func (e *Router) startSessionHandlerWeb(w http.ResponseWriter, r *http.Request)
 			UserBrowserVersion:   ua.BrowserVersion,
 			UserDevice:           ua.Device,
 			UserDeviceType:       ua.DeviceType,
-			UserCountry:          e.services.GeoIP.ExtractISOCodeFromHTTPRequest(r),
+			UserCountry:          geo.Country,
 			UserDeviceMemorySize: req.DeviceMemory,
 			UserDeviceHeapSize:   req.JsHeapSizeLimit,
 			UserID:               req.UserID,
@@ -128,15 +180,27 @@ func (e *Router) startSessionHandlerWeb(w http.ResponseWriter, r *http.Request)
 		if err := e.services.Producer.Produce(e.cfg.TopicRawWeb, tokenData.ID, Encode(sessionStart)); err != nil {
 			log.Printf("can't send session start: %s", err)
 		}
+		e.sendSessionMessage(e.cfg.TopicRawWeb, sessionID, sessionGeo(geo))
+		if skew := clockSkew(startTime, req.Timestamp); skew != 0 {
+			e.sendSessionMessage(e.cfg.TopicRawWeb, sessionID, &SessionClockSkew{ClockSkew: skew})
+		}
+		if previousSessionID != 0 {
+			e.sendSessionAssociation(e.cfg.TopicRawWeb, sessionID, previousSessionID, req.Timestamp)
+		}
+		e.services.Limiter.SessionStarted(r.Context(), p)
 	}
 
 	ResponseWithJSON(w, &StartSessionResponse{
-		Token:           e.services.Tokenizer.Compose(*tokenData),
-		UserUUID:        userUUID,
-		SessionID:       strconv.FormatUint(tokenData.ID, 10),
-		ProjectID:       strconv.FormatUint(uint64(p.ProjectID), 10),
-		BeaconSizeLimit: e.cfg.BeaconSizeLimit,
-		StartTimestamp:  int64(flakeid.ExtractTimestamp(tokenData.ID)),
+		Token:             e.services.Tokenizer.Compose(*tokenData),
+		UserUUID:          userUUID,
+		SessionID:         strconv.FormatUint(tokenData.ID, 10),
+		ProjectID:         strconv.FormatUint(uint64(p.ProjectID), 10),
+		BeaconSizeLimit:   e.cfg.BeaconSizeLimit,
+		StartTimestamp:    int64(flakeid.ExtractTimestamp(tokenData.ID)),
+		Timestamp:         time.Now().UnixMilli(),
+		Delay:             clockSkew(startTime, req.Timestamp),
+		PreviousSessionID: formatSessionID(previousSessionID),
+		CaptureSettings:   p.CaptureSettings,
 	})
 }
 
@@ -148,6 +212,19 @@ func (e *Router) pushMessagesHandlerWeb(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Project settings are required for the origin check and scrubbing, so the batch isn't accepted without them
+	p, err := e.sessionProject(sessionData)
+	if err != nil {
+		log.Printf("can't get project of session, sessID: %d, err: %s", sessionData.ID, err)
+		ResponseWithError(w, http.StatusInternalServerError, errors.New("can't get project"))
+		return
+	}
+	// Check request origin for projects with origin allowlist
+	if !e.checkOrigin(w, r, p) {
+		ResponseWithError(w, http.StatusForbidden, errors.New("origin is not allowed"))
+		return
+	}
+
 	// Check request body
 	if r.Body == nil {
 		ResponseWithError(w, http.StatusBadRequest, errors.New("request body is empty"))
@@ -157,15 +234,40 @@ func (e *Router) pushMessagesHandlerWeb(w http.ResponseWriter, r *http.Request)
 	bodyBytes, err := e.readBody(w, r, e.cfg.BeaconSizeLimit)
 	if err != nil {
 		log.Printf("error while reading request body: %s", err)
-		ResponseWithError(w, http.StatusRequestEntityTooLarge, err)
+		ResponseWithError(w, bodyErrorStatus(err), err)
+		return
+	}
+	if bodyBytes, err = decodeBatchFormat(r, bodyBytes); err != nil {
+		ResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if limitErr := e.services.Limiter.PushBytes(r.Context(), p.ProjectID, len(bodyBytes)); limitErr != nil {
+		ResponseWithRetryAfter(w, http.StatusTooManyRequests, limitErr.RetryAfter, limitErr)
+		return
+	}
+
+	// Make sure that consumers will be able to read the batch
+	if batchErr := ValidateBatch(bodyBytes, NewDecoderOptions(e.cfg.MessageSizeLimit)); batchErr != nil {
+		log.Printf("rejected batch, sessID: %d, err: %s", sessionData.ID, batchErr)
+		e.countRejectedBatch(r, sessionData, batchErr.Reason)
+		ResponseWithBatchError(w, batchErr)
+		return
+	}
+
+	// Mask personal data before it gets to the storage
+	if bodyBytes, err = e.services.Scrubber.ScrubBatch(r.Context(), p, bodyBytes); err != nil {
+		log.Printf("can't scrub batch, sessID: %d, err: %s", sessionData.ID, err)
+		ResponseWithError(w, http.StatusBadRequest, err)
 		return
 	}
 
 	// Send processed messages to queue as array of bytes
-	// TODO: check bytes for nonsense crap
 	err = e.services.Producer.Produce(e.cfg.TopicRawWeb, sessionData.ID, bodyBytes)
 	if err != nil {
 		log.Printf("can't send processed messages to queue: %s", err)
+		ResponseWithRetryAfter(w, http.StatusServiceUnavailable, e.cfg.SpoolRetryAfter, err)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -181,7 +283,7 @@ func (e *Router) notStartedHandlerWeb(w http.ResponseWriter, r *http.Request) {
 	bodyBytes, err := e.readBody(w, r, e.cfg.JsonSizeLimit)
 	if err != nil {
 		log.Printf("error while reading request body: %s", err)
-		ResponseWithError(w, http.StatusRequestEntityTooLarge, err)
+		ResponseWithError(w, bodyErrorStatus(err), err)
 		return
 	}
 
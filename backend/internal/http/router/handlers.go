@@ -1,40 +1,203 @@
 package router
 
 import (
-	gzip "github.com/klauspost/pgzip"
-	"io"
-	"io/ioutil"
+	"errors"
 	"log"
 	"net/http"
-)
+	"strconv"
 
-func (e *Router) pushMessages(w http.ResponseWriter, r *http.Request, sessionID uint64, topicName string) {
-	body := http.MaxBytesReader(w, r.Body, e.cfg.BeaconSizeLimit)
-	defer body.Close()
+	"openreplay/backend/internal/http/compression"
+	"openreplay/backend/internal/http/geoip"
+	"openreplay/backend/internal/http/screenshots"
+	"openreplay/backend/internal/http/util"
+	"openreplay/backend/pkg/db/postgres"
+	"openreplay/backend/pkg/db/types"
+	. "openreplay/backend/pkg/messages"
+	"openreplay/backend/pkg/token"
+)
 
-	var reader io.ReadCloser
-	var err error
+// bodyErrorStatus returns response status for errors of readBody: 413 for the body over the limit, 400 for the malformed one
+func bodyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, compression.ErrUnsupportedEncoding):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, compression.ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
 
-	switch r.Header.Get("Content-Encoding") {
-	case "gzip":
-		log.Println("Gzip", reader)
+// decodeBatchFormat converts the batch sent with ?format=json (debug mode for third-party trackers)
+// from JSON lines to the binary format
+func decodeBatchFormat(r *http.Request, body []byte) ([]byte, error) {
+	if r.URL.Query().Get("format") != "json" {
+		return body, nil
+	}
+	return BatchFromJSON(body)
+}
 
-		reader, err = gzip.NewReader(body)
-		if err != nil {
-			ResponseWithError(w, http.StatusInternalServerError, err) // TODO: stage-dependent response
-			return
+// sessionProject returns the project of the session, legacy tokens don't have project ID, so it's taken from the session
+func (e *Router) sessionProject(sessionData *token.TokenData) (*types.Project, error) {
+	projectID := sessionData.ProjectID
+	if projectID == 0 {
+		var err error
+		if projectID, err = e.services.Database.GetSessionProjectID(sessionData.ID); err != nil {
+			return nil, err
 		}
-		//log.Println("Gzip reader init", reader)
-		defer reader.Close()
-	default:
-		reader = body
 	}
-	//log.Println("Reader after switch:", reader)
-	buf, err := ioutil.ReadAll(reader)
+	return e.services.Database.GetProject(projectID)
+}
+
+func (e *Router) pushMessages(w http.ResponseWriter, r *http.Request, sessionData *token.TokenData, topicName string) {
+	// Scrub rules are required, so the batch isn't accepted without the project
+	p, err := e.sessionProject(sessionData)
+	if err != nil {
+		log.Printf("can't get project of session, sessID: %d, err: %s", sessionData.ID, err)
+		ResponseWithError(w, http.StatusInternalServerError, errors.New("can't get project"))
+		return
+	}
+	buf, err := e.readBody(w, r, e.cfg.BeaconSizeLimit)
 	if err != nil {
-		ResponseWithError(w, http.StatusInternalServerError, err) // TODO: send error here only on staging
+		log.Printf("error while reading request body: %s", err)
+		ResponseWithError(w, bodyErrorStatus(err), err)
+		return
+	}
+	if buf, err = decodeBatchFormat(r, buf); err != nil {
+		ResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+	if limitErr := e.services.Limiter.PushBytes(r.Context(), p.ProjectID, len(buf)); limitErr != nil {
+		ResponseWithRetryAfter(w, http.StatusTooManyRequests, limitErr.RetryAfter, limitErr)
+		return
+	}
+	if buf, err = e.services.Scrubber.ScrubBatch(r.Context(), p, buf); err != nil {
+		log.Printf("can't scrub batch, sessID: %d, err: %s", sessionData.ID, err)
+		ResponseWithError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := e.services.Producer.Produce(topicName, sessionData.ID, buf); err != nil {
+		log.Printf("can't send messages to queue: %s", err)
+		ResponseWithRetryAfter(w, http.StatusServiceUnavailable, e.cfg.SpoolRetryAfter, err)
 		return
 	}
-	e.services.Producer.Produce(topicName, sessionID, buf) // What if not able to send?
 	w.WriteHeader(http.StatusOK)
 }
+
+// expiredSessionID returns ID of the session with expired token, so the new session continues the same visit
+func expiredSessionID(tokenData *token.TokenData, err error, p *types.Project) uint64 {
+	if err != token.EXPIRED || tokenData == nil {
+		return 0
+	}
+	if tokenData.ProjectID != 0 && tokenData.ProjectID != p.ProjectID {
+		return 0
+	}
+	return tokenData.ID
+}
+
+func (e *Router) sendSessionAssociation(topicName string, sessionID, previousSessionID, timestamp uint64) {
+	msg := &SessionAssociation{
+		SessionID:         sessionID,
+		PreviousSessionID: previousSessionID,
+		Timestamp:         timestamp,
+	}
+	if err := e.services.Producer.Produce(topicName, sessionID, Encode(msg)); err != nil {
+		log.Printf("can't send session association: %s", err)
+	}
+}
+
+// sessionGeo keeps the location details which are not a part of session start messages
+func sessionGeo(geo *geoip.GeoRecord) *SessionGeo {
+	return &SessionGeo{
+		UserRegion:    geo.Region,
+		UserCity:      geo.City,
+		UserLatitude:  geo.Latitude,
+		UserLongitude: geo.Longitude,
+		UserTimezone:  geo.TimeZone,
+		UserASN:       geo.ASN,
+		UserASNOrg:    geo.ASNOrg,
+	}
+}
+
+// sendSessionMessage sends the message in a separate batch, so services which don't know it lose only this batch
+func (e *Router) sendSessionMessage(topicName string, sessionID uint64, msg Message) {
+	if err := e.services.Producer.Produce(topicName, sessionID, Encode(msg)); err != nil {
+		log.Printf("can't send message of type %d: %s", msg.TypeID(), err)
+	}
+}
+
+func formatSessionID(sessionID uint64) string {
+	if sessionID == 0 {
+		return ""
+	}
+	return strconv.FormatUint(sessionID, 10)
+}
+
+// imagesUploadHandler saves screenshots of mobile sessions (both iOS and Android)
+func (e *Router) imagesUploadHandler(w http.ResponseWriter, r *http.Request) {
+	sessionData, err := e.services.Tokenizer.ParseFromHTTPRequest(r)
+	if err != nil { // Should accept expired token?
+		ResponseWithError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	if r.Body == nil {
+		ResponseWithError(w, http.StatusBadRequest, errors.New("request body is empty"))
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, e.cfg.FileSizeLimit)
+	defer r.Body.Close()
+
+	err = r.ParseMultipartForm(1e6) // ~1Mb
+	if err == http.ErrNotMultipart || err == http.ErrMissingBoundary {
+		ResponseWithError(w, http.StatusUnsupportedMediaType, err)
+		return
+		// } else if err == multipart.ErrMessageTooLarge // if non-files part exceeds 10 MB
+	} else if err != nil {
+		ResponseWithError(w, http.StatusInternalServerError, err) // TODO: send error here only on staging
+		return
+	}
+
+	if r.MultipartForm == nil {
+		ResponseWithError(w, http.StatusInternalServerError, errors.New("Multipart not parsed"))
+		return
+	}
+
+	// Project key is a part of the session key of screenshots
+	var p *types.Project
+	if sessionData.ProjectID != 0 {
+		p, err = e.services.Database.GetProject(sessionData.ProjectID)
+	} else if len(r.MultipartForm.Value["projectKey"]) != 0 { // Tokens composed before project was added to the token
+		p, err = e.services.Database.GetProjectByKey(r.MultipartForm.Value["projectKey"][0])
+	} else {
+		ResponseWithError(w, http.StatusBadRequest, errors.New("projectKey parameter missing"))
+		return
+	}
+	if err != nil {
+		if postgres.IsNoRowsErr(err) {
+			ResponseWithError(w, http.StatusNotFound, errors.New("Project doesn't exist or is not active"))
+		} else {
+			ResponseWithError(w, http.StatusInternalServerError, err) // TODO: send error here only on staging
+		}
+		return
+	}
+
+	res := &ImagesUploadResponse{Files: []*ImageUploadResult{}}
+	for _, fileHeaderList := range r.MultipartForm.File {
+		for _, fileHeader := range fileHeaderList {
+			result := &ImageUploadResult{Name: fileHeader.Filename}
+			res.Files = append(res.Files, result)
+			file, err := fileHeader.Open()
+			if err != nil {
+				result.Status, result.Error = screenshots.StatusFailed, err.Error()
+				continue
+			}
+			result.Hash, result.Status, err = e.services.Images.Upload(uint64(p.ProjectID), p.ProjectKey, sessionData.ID, fileHeader.Filename, file)
+			file.Close()
+			if err != nil {
+				log.Printf("Upload ios screen error, file: %s, err: %s", util.SafeString(fileHeader.Filename), err)
+				result.Error = err.Error()
+			}
+		}
+	}
+	ResponseWithJSON(w, res)
+}
@@ -0,0 +1,49 @@
+package router
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"openreplay/backend/internal/http/compression"
+)
+
+func gzipped(data []byte) []byte {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	w.Write(data)
+	w.Close()
+	return buf.Bytes()
+}
+
+// Body is read the same way as in readBody
+func TestBodyErrorStatus(t *testing.T) {
+	const limit = 100
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		status   int
+	}{
+		{"valid", "gzip", gzipped([]byte("batch")), http.StatusOK},
+		{"over the limit", "", make([]byte, 10*limit), http.StatusRequestEntityTooLarge},
+		{"decompressed over the limit", "gzip", gzipped(make([]byte, 10*limit)), http.StatusRequestEntityTooLarge},
+		{"malformed", "gzip", []byte("batch"), http.StatusBadRequest},
+		{"unsupported encoding", "compress", []byte("batch"), http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := http.MaxBytesReader(httptest.NewRecorder(), io.NopCloser(bytes.NewReader(tt.body)), limit+1)
+			status := http.StatusOK
+			if _, err := compression.ReadAll(body, tt.encoding, limit); err != nil {
+				status = bodyErrorStatus(err)
+			}
+			if status != tt.status {
+				t.Errorf("got %d, want %d", status, tt.status)
+			}
+		})
+	}
+}
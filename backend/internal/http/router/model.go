@@ -1,5 +1,12 @@
 package router
 
+import (
+	"encoding/json"
+
+	"openreplay/backend/pkg/db/types"
+	"openreplay/backend/pkg/rrweb"
+)
+
 type StartSessionRequest struct {
 	Token           string  `json:"token"`
 	UserUUID        *string `json:"userUUID"`
@@ -12,17 +19,20 @@ type StartSessionRequest struct {
 	ProjectKey      *string `json:"projectKey"`
 	Reset           bool    `json:"reset"`
 	UserID          string  `json:"userID"`
+	URL             string  `json:"url"`
 }
 
 type StartSessionResponse struct {
-	Timestamp       int64  `json:"timestamp"`
-	StartTimestamp  int64  `json:"startTimestamp"`
-	Delay           int64  `json:"delay"`
-	Token           string `json:"token"`
-	UserUUID        string `json:"userUUID"`
-	SessionID       string `json:"sessionID"`
-	ProjectID       string `json:"projectID"`
-	BeaconSizeLimit int64  `json:"beaconSizeLimit"`
+	Timestamp         int64                  `json:"timestamp"` // server's time of the response, lets tracker compute its offset
+	StartTimestamp    int64                  `json:"startTimestamp"`
+	Delay             int64                  `json:"delay"` // server's time minus user's time of the request if it exceeds latency, ms
+	Token             string                 `json:"token"`
+	UserUUID          string                 `json:"userUUID"`
+	SessionID         string                 `json:"sessionID"`
+	ProjectID         string                 `json:"projectID"`
+	BeaconSizeLimit   int64                  `json:"beaconSizeLimit"`
+	PreviousSessionID string                 `json:"previousSessionID,omitempty"`
+	CaptureSettings   *types.CaptureSettings `json:"captureSettings,omitempty"`
 }
 
 type NotStartedRequest struct {
@@ -43,9 +53,79 @@ type StartIOSSessionRequest struct {
 }
 
 type StartIOSSessionResponse struct {
-	Token           string   `json:"token"`
-	ImagesHashList  []string `json:"imagesHashList"`
-	UserUUID        string   `json:"userUUID"`
-	BeaconSizeLimit int64    `json:"beaconSizeLimit"`
-	SessionID       string   `json:"sessionID"`
+	Token             string                 `json:"token"`
+	ImagesHashList    []string               `json:"imagesHashList"`
+	UserUUID          string                 `json:"userUUID"`
+	BeaconSizeLimit   int64                  `json:"beaconSizeLimit"`
+	SessionID         string                 `json:"sessionID"`
+	PreviousSessionID string                 `json:"previousSessionID,omitempty"`
+	CaptureSettings   *types.CaptureSettings `json:"captureSettings,omitempty"`
+}
+
+type StartAndroidSessionRequest struct {
+	Token          string  `json:"token"`
+	ProjectKey     *string `json:"projectKey"`
+	TrackerVersion string  `json:"trackerVersion"`
+	RevID          string  `json:"revID"`
+	UserUUID       *string `json:"userUUID"`
+	UserOSVersion  string  `json:"userOSVersion"`
+	UserDevice     string  `json:"userDevice"`
+	UserDeviceType string  `json:"userDeviceType"`
+	Timestamp      uint64  `json:"timestamp"`
+}
+
+type StartAndroidSessionResponse struct {
+	Token             string                 `json:"token"`
+	ImagesHashList    []string               `json:"imagesHashList"`
+	UserUUID          string                 `json:"userUUID"`
+	BeaconSizeLimit   int64                  `json:"beaconSizeLimit"`
+	SessionID         string                 `json:"sessionID"`
+	PreviousSessionID string                 `json:"previousSessionID,omitempty"`
+	CaptureSettings   *types.CaptureSettings `json:"captureSettings,omitempty"`
+}
+
+type ImageUploadResult struct {
+	Name   string `json:"name"`
+	Hash   string `json:"hash,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ImagesUploadResponse struct {
+	Files []*ImageUploadResult `json:"files"`
+}
+
+type ServerEventsRequest struct {
+	SessionID string            `json:"sessionID"`
+	UserID    string            `json:"userID"`
+	Metadata  []*ServerMetadata `json:"metadata"`
+	Events    []*ServerEvent    `json:"events"`
+	Issues    []*ServerEvent    `json:"issues"`
+}
+
+type ServerMetadata struct {
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Timestamp int64  `json:"timestamp"` // Time of the request if empty
+}
+
+type ServerEvent struct {
+	Name      string          `json:"name"`
+	Payload   json.RawMessage `json:"payload"`
+	Timestamp int64           `json:"timestamp"`
+}
+
+type ImportSessionRequest struct {
+	Events         []*rrweb.Event    `json:"events"`
+	UserUUID       *string           `json:"userUUID"`
+	UserID         string            `json:"userID"`
+	UserAgent      string            `json:"userAgent"`
+	UserCountry    string            `json:"userCountry"`
+	RevID          string            `json:"revID"`
+	TrackerVersion string            `json:"trackerVersion"`
+	Metadata       map[string]string `json:"metadata"`
+}
+
+type ImportSessionResponse struct {
+	SessionID string `json:"sessionID"`
 }
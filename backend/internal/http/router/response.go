@@ -3,7 +3,12 @@ package router
 import (
 	"encoding/json"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
+
+	"openreplay/backend/pkg/messages"
 )
 
 func ResponseWithJSON(w http.ResponseWriter, res interface{}) {
@@ -22,3 +27,19 @@ func ResponseWithError(w http.ResponseWriter, code int, err error) {
 	w.WriteHeader(code)
 	ResponseWithJSON(w, &response{err.Error()})
 }
+
+func ResponseWithRetryAfter(w http.ResponseWriter, code int, retryAfter time.Duration, err error) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	ResponseWithError(w, code, err)
+}
+
+// ResponseWithBatchError reports the rejected batch with the index of the first broken message
+func ResponseWithBatchError(w http.ResponseWriter, err *messages.BatchError) {
+	type response struct {
+		Error  string `json:"error"`
+		Reason string `json:"reason"`
+		Index  uint64 `json:"index"`
+	}
+	w.WriteHeader(http.StatusBadRequest)
+	ResponseWithJSON(w, &response{err.Error(), err.Reason, err.Index})
+}
@@ -9,8 +9,10 @@ import (
 	"log"
 	"net/http"
 	http3 "openreplay/backend/internal/config/http"
+	"openreplay/backend/internal/http/origin"
 	http2 "openreplay/backend/internal/http/services"
 	"openreplay/backend/internal/http/util"
+	"openreplay/backend/pkg/db/types"
 	"openreplay/backend/pkg/monitoring"
 	"time"
 )
@@ -22,6 +24,8 @@ type Router struct {
 	requestSize     syncfloat64.Histogram
 	requestDuration syncfloat64.Histogram
 	totalRequests   syncfloat64.Counter
+	rejectedBatches syncfloat64.Counter
+	blockedOrigins  syncfloat64.Counter
 }
 
 func NewRouter(cfg *http3.Config, services *http2.ServicesBuilder, metrics *monitoring.Metrics) (*Router, error) {
@@ -52,10 +56,16 @@ func (e *Router) init() {
 		"/v1/web/not-started": e.notStartedHandlerWeb,
 		"/v1/web/start":       e.startSessionHandlerWeb,
 		"/v1/web/i":           e.pushMessagesHandlerWeb,
+		"/v1/web/import":      e.importHandlerWeb,
 		"/v1/ios/start":       e.startSessionHandlerIOS,
 		"/v1/ios/i":           e.pushMessagesHandlerIOS,
 		"/v1/ios/late":        e.pushLateMessagesHandlerIOS,
-		"/v1/ios/images":      e.imagesUploadHandlerIOS,
+		"/v1/ios/images":      e.imagesUploadHandler,
+		"/v1/android/start":   e.startSessionHandlerAndroid,
+		"/v1/android/i":       e.pushMessagesHandlerAndroid,
+		"/v1/android/late":    e.pushLateMessagesHandlerAndroid,
+		"/v1/android/images":  e.imagesUploadHandler,
+		"/v1/server/events":   e.eventsHandlerServer,
 	}
 	prefix := "/ingest"
 
@@ -82,6 +92,14 @@ func (e *Router) initMetrics(metrics *monitoring.Metrics) {
 	if err != nil {
 		log.Printf("can't create requests_total metric: %s", err)
 	}
+	e.rejectedBatches, err = metrics.RegisterCounter("batches_rejected")
+	if err != nil {
+		log.Printf("can't create batches_rejected metric: %s", err)
+	}
+	e.blockedOrigins, err = metrics.RegisterCounter("origins_blocked")
+	if err != nil {
+		log.Printf("can't create origins_blocked metric: %s", err)
+	}
 }
 
 func (e *Router) root(w http.ResponseWriter, r *http.Request) {
@@ -93,7 +111,7 @@ func (e *Router) corsMiddleware(next http.Handler) http.Handler {
 		// Prepare headers for preflight requests
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "POST")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type,Content-Encoding,Authorization")
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Cache-Control", "max-age=86400")
 			w.WriteHeader(http.StatusOK)
@@ -116,6 +134,27 @@ func (e *Router) corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// checkOrigin returns false if the project has an origin allowlist and request origin doesn't match it
+func (e *Router) checkOrigin(w http.ResponseWriter, r *http.Request, p *types.Project) bool {
+	if len(p.AllowedOrigins) == 0 {
+		return true
+	}
+	requestOrigin := origin.FromHTTPRequest(r)
+	if !origin.IsAllowed(p.AllowedOrigins, requestOrigin) {
+		log.Printf("blocked request from origin %s, projectID: %d", util.SafeString(requestOrigin), p.ProjectID)
+		e.blockedOrigins.Add(
+			r.Context(),
+			1,
+			[]attribute.KeyValue{attribute.Int64("project_id", int64(p.ProjectID)), attribute.String("method", r.URL.Path)}...,
+		)
+		return false
+	}
+	// Response is readable only by allowed origin
+	w.Header().Set("Access-Control-Allow-Origin", requestOrigin)
+	w.Header().Add("Vary", "Origin")
+	return true
+}
+
 func (e *Router) GetHandler() http.Handler {
 	return e.router
 }
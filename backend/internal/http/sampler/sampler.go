@@ -0,0 +1,69 @@
+package sampler
+
+import (
+	"hash/fnv"
+	"net/url"
+	"strings"
+
+	"openreplay/backend/pkg/db/types"
+)
+
+// Session contains the properties of a new session that sample rules can match
+type Session struct {
+	UserUUID       string
+	UserID         string
+	Country        string
+	URL            string
+	TrackerVersion string
+}
+
+// IsSampled returns the same decision for the same device (UserUUID) on every visit.
+// The first matching project rule defines the rate, otherwise project sample rate is used.
+func IsSampled(p *types.Project, s *Session) bool {
+	rate := p.SampleRate
+	for i := range p.SampleRules {
+		if matchRule(&p.SampleRules[i], s) {
+			rate = p.SampleRules[i].Rate
+			break
+		}
+	}
+	return dice(p.ProjectKey, s) < rate
+}
+
+func matchRule(rule *types.SampleRule, s *Session) bool {
+	if rule.Country != "" && !strings.EqualFold(rule.Country, s.Country) {
+		return false
+	}
+	if rule.URLPrefix != "" && !matchURLPrefix(rule.URLPrefix, s.URL) {
+		return false
+	}
+	if rule.TrackerVersion != "" && !strings.HasPrefix(s.TrackerVersion, rule.TrackerVersion) {
+		return false
+	}
+	if rule.LoggedIn && s.UserID == "" {
+		return false
+	}
+	return true
+}
+
+// matchURLPrefix compares only the path if the prefix starts with "/"
+func matchURLPrefix(prefix, rawURL string) bool {
+	if !strings.HasPrefix(prefix, "/") {
+		return strings.HasPrefix(rawURL, prefix)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(u.Path, prefix)
+}
+
+// dice returns a stable value in [0, 100) for the user of the project.
+// UserUUID is used even if UserID is known: UserID may be set later during the visit and
+// mobile trackers don't send it on start, so the decision would change between sessions.
+func dice(projectKey string, s *Session) byte {
+	h := fnv.New32a()
+	h.Write([]byte(projectKey))
+	h.Write([]byte(s.UserUUID))
+	return byte(h.Sum32() % 100)
+}
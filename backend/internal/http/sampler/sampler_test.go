@@ -0,0 +1,91 @@
+package sampler
+
+import (
+	"fmt"
+	"testing"
+
+	"openreplay/backend/pkg/db/types"
+)
+
+func TestIsSampledDeterministic(t *testing.T) {
+	p := &types.Project{ProjectKey: "key", SampleRate: 50}
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		s := &Session{UserUUID: fmt.Sprintf("uuid-%d", i)}
+		decision := IsSampled(p, s)
+		// Same device gets the same decision on every visit, even after login
+		for _, next := range []*Session{{UserUUID: s.UserUUID}, {UserUUID: s.UserUUID, UserID: "user"}} {
+			if IsSampled(p, next) != decision {
+				t.Fatalf("decision for %s changed", s.UserUUID)
+			}
+		}
+		if decision {
+			sampled++
+		}
+	}
+	if sampled < 400 || sampled > 600 {
+		t.Errorf("%d of 1000 sessions are sampled with rate 50", sampled)
+	}
+}
+
+func TestIsSampledRate(t *testing.T) {
+	s := &Session{UserUUID: "uuid"}
+	tests := []struct {
+		name string
+		rate byte
+		want bool
+	}{
+		{"nothing", 0, false},
+		{"everything", 100, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSampled(&types.Project{ProjectKey: "key", SampleRate: tt.rate}, s); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSampledRules(t *testing.T) {
+	s := &Session{
+		UserUUID:       "uuid",
+		UserID:         "user",
+		Country:        "DE",
+		URL:            "https://example.com/checkout/cart?id=1",
+		TrackerVersion: "4.1.2",
+	}
+	anonymous := *s
+	anonymous.UserID = ""
+	tests := []struct {
+		name    string
+		rules   []types.SampleRule
+		session *Session
+		want    bool
+	}{
+		{"no rules", nil, s, false},
+		{"empty rule matches all", []types.SampleRule{{Rate: 100}}, s, true},
+		{"country", []types.SampleRule{{Country: "de", Rate: 100}}, s, true},
+		{"other country", []types.SampleRule{{Country: "FR", Rate: 100}}, s, false},
+		{"URL prefix", []types.SampleRule{{URLPrefix: "https://example.com/checkout", Rate: 100}}, s, true},
+		{"other URL prefix", []types.SampleRule{{URLPrefix: "https://example.org/", Rate: 100}}, s, false},
+		{"path prefix", []types.SampleRule{{URLPrefix: "/checkout/", Rate: 100}}, s, true},
+		{"path prefix of query", []types.SampleRule{{URLPrefix: "/cart", Rate: 100}}, s, false},
+		{"tracker version", []types.SampleRule{{TrackerVersion: "4.1", Rate: 100}}, s, true},
+		{"other tracker version", []types.SampleRule{{TrackerVersion: "3.", Rate: 100}}, s, false},
+		{"logged in", []types.SampleRule{{LoggedIn: true, Rate: 100}}, s, true},
+		{"anonymous", []types.SampleRule{{LoggedIn: true, Rate: 100}}, &anonymous, false},
+		{"all conditions", []types.SampleRule{{Country: "DE", URLPrefix: "/checkout", TrackerVersion: "4", LoggedIn: true, Rate: 100}}, s, true},
+		{"one condition fails", []types.SampleRule{{Country: "DE", URLPrefix: "/account", Rate: 100}}, s, false},
+		{"first match wins", []types.SampleRule{{Country: "DE", Rate: 0}, {Rate: 100}}, s, false},
+		{"next rule matches", []types.SampleRule{{Country: "FR", Rate: 0}, {Rate: 100}}, s, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &types.Project{ProjectKey: "key", SampleRate: 0, SampleRules: tt.rules}
+			if got := IsSampled(p, tt.session); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
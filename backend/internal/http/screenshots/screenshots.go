@@ -0,0 +1,170 @@
+package screenshots
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"openreplay/backend/pkg/storage"
+)
+
+// Upload results
+const (
+	StatusUploaded = "uploaded"
+	StatusExists   = "exists"
+	StatusFailed   = "failed"
+)
+
+// hashList keeps hashes of the project's screenshots, the most recent ones first
+type hashList struct {
+	hashes    []string
+	updatedAt time.Time
+	loading   bool
+}
+
+// add moves the hash to the beginning of the list
+func (l *hashList) add(hash string) {
+	for i, h := range l.hashes {
+		if h == hash {
+			copy(l.hashes[1:i+1], l.hashes[:i])
+			l.hashes[0] = hash
+			return
+		}
+	}
+	if len(l.hashes) < storage.MAX_RETURNING_COUNT {
+		l.hashes = append(l.hashes, "")
+	}
+	copy(l.hashes[1:], l.hashes)
+	l.hashes[0] = hash
+}
+
+// reset fills the list with the listed keys, they go after the hashes uploaded since the process start
+func (l *hashList) reset(keys []string) {
+	recent := l.hashes
+	l.hashes = nil
+	for i := len(keys) - 1; i >= 0; i-- {
+		l.add(keys[i])
+	}
+	for i := len(recent) - 1; i >= 0; i-- {
+		l.add(recent[i])
+	}
+}
+
+// Storage keeps iOS screenshots in the bucket by content hash: <projectID>/<sha256>. Every uploaded file is also
+// saved under the session key <projectKey>/<sessionID>/<name>, which is the key the player loads screenshots by.
+type Storage struct {
+	s3     *storage.S3
+	ttl    time.Duration
+	mutex  sync.Mutex
+	hashes map[uint64]*hashList
+}
+
+func New(s3 *storage.S3, ttl time.Duration) *Storage {
+	return &Storage{
+		s3:     s3,
+		ttl:    ttl,
+		hashes: make(map[uint64]*hashList),
+	}
+}
+
+// Ping checks that the images bucket is accessible
+func (s *Storage) Ping() error {
+	return s.s3.Ping()
+}
+
+// HashList returns hashes of recently uploaded screenshots, so SDK doesn't need to send them again.
+// Hashes of uploads are tracked in memory, the bucket is listed only to fill the list after the start or ttl.
+func (s *Storage) HashList(projectID uint64) []string {
+	s.mutex.Lock()
+	list, ok := s.hashes[projectID]
+	if !ok {
+		list = &hashList{}
+		s.hashes[projectID] = list
+	}
+	if list.loading || time.Since(list.updatedAt) < s.ttl {
+		hashes := append([]string{}, list.hashes...)
+		s.mutex.Unlock()
+		return hashes
+	}
+	list.loading = true
+	s.mutex.Unlock()
+
+	// Don't keep other starts waiting for S3
+	keys, err := s.s3.GetFrequentlyUsedKeys(projectID)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	list.loading = false
+	list.updatedAt = time.Now()
+	if err != nil {
+		log.Printf("can't get screenshots hash list for project %d: %s", projectID, err)
+	}
+	list.reset(keys)
+	return append([]string{}, list.hashes...)
+}
+
+func (s *Storage) isKnown(projectID uint64, hash string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	list, ok := s.hashes[projectID]
+	if !ok {
+		return false
+	}
+	for _, h := range list.hashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Storage) remember(projectID uint64, hash string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	list, ok := s.hashes[projectID]
+	if !ok {
+		list = &hashList{}
+		s.hashes[projectID] = list
+	}
+	list.add(hash)
+}
+
+// Upload saves the screenshot under the session key and by its hash if there isn't the same one yet.
+// It returns the hash with the upload status.
+func (s *Storage) Upload(projectID uint64, projectKey string, sessionID uint64, name string, file io.Reader) (string, string, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", StatusFailed, fmt.Errorf("can't read file: %s", err)
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return hash, StatusFailed, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+
+	sessionKey := projectKey + "/" + strconv.FormatUint(sessionID, 10) + "/" + name
+	if err := s.s3.Upload(bytes.NewReader(data), sessionKey, contentType, false); err != nil {
+		return hash, StatusFailed, fmt.Errorf("can't upload file: %s", err)
+	}
+
+	key := strconv.FormatUint(projectID, 10) + "/" + hash
+	if s.isKnown(projectID, hash) || s.s3.Exists(key) {
+		s.remember(projectID, hash)
+		return hash, StatusExists, nil
+	}
+	if err := s.s3.Upload(bytes.NewReader(data), key, contentType, false); err != nil {
+		return hash, StatusFailed, fmt.Errorf("can't upload file: %s", err)
+	}
+	s.remember(projectID, hash)
+	return hash, StatusUploaded, nil
+}
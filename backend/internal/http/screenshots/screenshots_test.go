@@ -0,0 +1,76 @@
+package screenshots
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"openreplay/backend/pkg/storage"
+)
+
+func hashes(n int, prefix string) []string {
+	res := make([]string, n)
+	for i := range res {
+		res[i] = fmt.Sprintf("%s%d", prefix, i)
+	}
+	return res
+}
+
+func TestHashListAdd(t *testing.T) {
+	full := hashes(storage.MAX_RETURNING_COUNT, "h")
+	tests := []struct {
+		name   string
+		hashes []string
+		add    []string
+		want   []string
+	}{
+		{"empty", nil, []string{"a"}, []string{"a"}},
+		{"new first", []string{"a", "b"}, []string{"c"}, []string{"c", "a", "b"}},
+		{"several", nil, []string{"a", "b", "c"}, []string{"c", "b", "a"}},
+		{"known to the front", []string{"a", "b", "c"}, []string{"c"}, []string{"c", "a", "b"}},
+		{"known in the middle", []string{"a", "b", "c"}, []string{"b"}, []string{"b", "a", "c"}},
+		{"first stays", []string{"a", "b", "c"}, []string{"a"}, []string{"a", "b", "c"}},
+		{"oldest is dropped", full, []string{"new"}, append([]string{"new"}, full[:len(full)-1]...)},
+		{"known in the full list", full, []string{full[len(full)-1]}, append([]string{full[len(full)-1]}, full[:len(full)-1]...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &hashList{hashes: append([]string{}, tt.hashes...)}
+			for _, h := range tt.add {
+				l.add(h)
+			}
+			if !reflect.DeepEqual(l.hashes, tt.want) {
+				t.Errorf("got %v, want %v", l.hashes, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashListReset(t *testing.T) {
+	listed := hashes(storage.MAX_RETURNING_COUNT, "s")
+	tests := []struct {
+		name   string
+		recent []string
+		keys   []string
+		want   []string
+	}{
+		{"nothing", nil, nil, nil},
+		{"listed only", nil, []string{"a", "b"}, []string{"a", "b"}},
+		{"recent first", []string{"c", "d"}, []string{"a", "b"}, []string{"c", "d", "a", "b"}},
+		{"recent and listed", []string{"b", "c"}, []string{"a", "b"}, []string{"b", "c", "a"}},
+		{"recent only", []string{"c"}, nil, []string{"c"}},
+		{"listed are cut", []string{"c"}, listed, append([]string{"c"}, listed[:len(listed)-1]...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &hashList{hashes: append([]string{}, tt.recent...)}
+			l.reset(tt.keys)
+			if len(l.hashes) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(l.hashes, tt.want) {
+				t.Errorf("got %v, want %v", l.hashes, tt.want)
+			}
+		})
+	}
+}
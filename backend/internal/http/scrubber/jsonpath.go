@@ -0,0 +1,65 @@
+package scrubber
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// parsePath splits path like "$.user.email" or "headers.*" into keys.
+// Arrays are traversed implicitly, "*" matches any key.
+func parsePath(path string) []string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+// maskJSON replaces values found by paths with mask and writes the new json into res.
+// The value is left untouched if it isn't a json or nothing was found.
+func maskJSON(value string, paths [][]string, mask string, res *string) int {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(value), &doc); err != nil {
+		return 0
+	}
+	matches := 0
+	for _, path := range paths {
+		if len(path) == 0 {
+			continue
+		}
+		doc = maskPath(doc, path, mask, &matches)
+	}
+	if matches == 0 {
+		return 0
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return 0
+	}
+	*res = string(data)
+	return matches
+}
+
+func maskPath(node interface{}, path []string, mask string, matches *int) interface{} {
+	if len(path) == 0 {
+		*matches++
+		return mask
+	}
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, child := range n {
+			if path[0] == "*" || path[0] == key {
+				n[key] = maskPath(child, path[1:], mask, matches)
+			}
+		}
+	case []interface{}:
+		for i, child := range n {
+			n[i] = maskPath(child, path, mask, matches)
+		}
+	case string:
+		// Payloads often contain serialized json (e.g. request body inside of the fetch request)
+		*matches += maskJSON(n, [][]string{path}, mask, &n)
+		return n
+	}
+	return node
+}
@@ -0,0 +1,162 @@
+package scrubber
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+
+	"openreplay/backend/pkg/db/types"
+	. "openreplay/backend/pkg/messages"
+	"openreplay/backend/pkg/monitoring"
+)
+
+// ErrNoProject is returned if the project with scrub rules isn't loaded, the batch mustn't be sent then
+var ErrNoProject = errors.New("project scrub rules are not loaded")
+
+// Scrubber masks personal data in batches according to project scrub rules
+type Scrubber struct {
+	mask     string
+	rules    sync.Map // projectID -> *projectRules
+	scrubbed syncfloat64.Counter
+}
+
+type projectRules struct {
+	project  *types.Project // Rules are compiled again when project is reloaded from db
+	patterns []*regexp.Regexp
+	paths    [][]string
+}
+
+func New(mask string, metrics *monitoring.Metrics) *Scrubber {
+	scrubbed, err := metrics.RegisterCounter("scrubbed_values")
+	if err != nil {
+		log.Printf("can't create scrubbed_values metric: %s", err)
+	}
+	return &Scrubber{
+		mask:     mask,
+		scrubbed: scrubbed,
+	}
+}
+
+// ScrubBatch returns batch with masked values or the original data if nothing was masked.
+// The batch which can't be checked (no project or broken messages) is refused with an error.
+func (s *Scrubber) ScrubBatch(ctx context.Context, p *types.Project, data []byte) ([]byte, error) {
+	if p == nil {
+		return nil, ErrNoProject
+	}
+	if len(p.ScrubRules) == 0 {
+		return data, nil
+	}
+	rules := s.getRules(p)
+	if len(rules.patterns) == 0 && len(rules.paths) == 0 {
+		return data, nil
+	}
+	return RewriteBatch(data, isScrubbable, func(msg Message) Message {
+		matches := s.scrubMessage(rules, msg)
+		if matches == 0 {
+			return nil
+		}
+		if s.scrubbed != nil {
+			s.scrubbed.Add(
+				ctx,
+				float64(matches),
+				[]attribute.KeyValue{attribute.Int64("project_id", int64(p.ProjectID)), attribute.Int("type", msg.TypeID())}...,
+			)
+		}
+		return msg
+	})
+}
+
+func (s *Scrubber) getRules(p *types.Project) *projectRules {
+	if cached, ok := s.rules.Load(p.ProjectID); ok && cached.(*projectRules).project == p {
+		return cached.(*projectRules)
+	}
+	rules := &projectRules{project: p}
+	for _, rule := range p.ScrubRules {
+		if rule.Pattern != "" {
+			re, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				log.Printf("can't compile scrub pattern, projectID: %d, err: %s", p.ProjectID, err)
+			} else {
+				rules.patterns = append(rules.patterns, re)
+			}
+		}
+		if rule.JSONPath != "" {
+			rules.paths = append(rules.paths, parsePath(rule.JSONPath))
+		}
+	}
+	s.rules.Store(p.ProjectID, rules)
+	return rules
+}
+
+func isScrubbable(msgType int) bool {
+	switch msgType {
+	case MsgSetInputValue, MsgSetNodeData, MsgConsoleLog, MsgMetadata,
+		MsgFetch, MsgFetchEvent, MsgGraphQL, MsgGraphQLEvent,
+		// iOS and Android send the same mobile messages
+		MsgIOSInputEvent, MsgIOSLog, MsgIOSMetadata, MsgIOSNetworkCall:
+		return true
+	}
+	return false
+}
+
+// scrubMessage masks values of the message in place and returns the number of matches
+func (s *Scrubber) scrubMessage(rules *projectRules, msg Message) int {
+	matches := 0
+	switch m := msg.(type) {
+	case *SetInputValue:
+		matches += s.scrubText(rules, &m.Value)
+	case *SetNodeData:
+		matches += s.scrubText(rules, &m.Data)
+	case *ConsoleLog:
+		matches += s.scrubText(rules, &m.Value)
+	case *Metadata:
+		matches += s.scrubText(rules, &m.Value)
+	case *Fetch:
+		matches += s.scrubBody(rules, &m.Request)
+		matches += s.scrubBody(rules, &m.Response)
+	case *FetchEvent:
+		matches += s.scrubBody(rules, &m.Request)
+		matches += s.scrubBody(rules, &m.Response)
+	case *GraphQL:
+		matches += s.scrubBody(rules, &m.Variables)
+		matches += s.scrubBody(rules, &m.Response)
+	case *GraphQLEvent:
+		matches += s.scrubBody(rules, &m.Variables)
+		matches += s.scrubBody(rules, &m.Response)
+	case *IOSInputEvent:
+		matches += s.scrubText(rules, &m.Value)
+	case *IOSLog:
+		matches += s.scrubText(rules, &m.Content)
+	case *IOSMetadata:
+		matches += s.scrubText(rules, &m.Value)
+	case *IOSNetworkCall:
+		matches += s.scrubBody(rules, &m.Headers)
+		matches += s.scrubBody(rules, &m.Body)
+	}
+	return matches
+}
+
+func (s *Scrubber) scrubText(rules *projectRules, value *string) int {
+	matches := 0
+	for _, re := range rules.patterns {
+		*value = re.ReplaceAllStringFunc(*value, func(string) string {
+			matches++
+			return s.mask
+		})
+	}
+	return matches
+}
+
+// scrubBody applies json paths to request/response payloads before the patterns
+func (s *Scrubber) scrubBody(rules *projectRules, value *string) int {
+	matches := 0
+	if len(rules.paths) > 0 {
+		matches += maskJSON(*value, rules.paths, s.mask, value)
+	}
+	return matches + s.scrubText(rules, value)
+}
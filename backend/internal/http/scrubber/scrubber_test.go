@@ -0,0 +1,152 @@
+package scrubber
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"openreplay/backend/pkg/db/types"
+	. "openreplay/backend/pkg/messages"
+)
+
+const mask = "***"
+
+func TestMaskJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		paths   []string
+		want    string
+		matches int
+	}{
+		{"field", `{"user":{"email":"a@b.c","name":"A"}}`, []string{"$.user.email"}, `{"user":{"email":"***","name":"A"}}`, 1},
+		{"without $", `{"user":{"email":"a@b.c"}}`, []string{"user.email"}, `{"user":{"email":"***"}}`, 1},
+		{"object", `{"user":{"email":"a@b.c"},"id":1}`, []string{"$.user"}, `{"id":1,"user":"***"}`, 1},
+		{"wildcard", `{"headers":{"a":"1","b":"2"}}`, []string{"$.headers.*"}, `{"headers":{"a":"***","b":"***"}}`, 2},
+		{"array", `{"users":[{"email":"a"},{"email":"b"}]}`, []string{"$.users.email"}, `{"users":[{"email":"***"},{"email":"***"}]}`, 2},
+		{"top level array", `[{"token":"a"},{"id":1}]`, []string{"$.token"}, `[{"token":"***"},{"id":1}]`, 1},
+		{"serialized json", `{"body":"{\"password\":\"p\"}"}`, []string{"$.body.password"}, `{"body":"{\"password\":\"***\"}"}`, 1},
+		{"several paths", `{"a":1,"b":2,"c":3}`, []string{"$.a", "$.c"}, `{"a":"***","b":2,"c":"***"}`, 2},
+		{"not found", `{"user":{"name":"A"}}`, []string{"$.user.email"}, `{"user":{"name":"A"}}`, 0},
+		{"root path", `{"a":1}`, []string{"$"}, `{"a":1}`, 0},
+		{"not json", `email=a@b.c`, []string{"$.email"}, `email=a@b.c`, 0},
+		{"scalar", `"a@b.c"`, []string{"$.email"}, `"a@b.c"`, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths [][]string
+			for _, path := range tt.paths {
+				paths = append(paths, parsePath(path))
+			}
+			got := tt.value
+			matches := maskJSON(tt.value, paths, mask, &got)
+			if got != tt.want || matches != tt.matches {
+				t.Errorf("got %s with %d matches, want %s with %d", got, matches, tt.want, tt.matches)
+			}
+		})
+	}
+}
+
+func TestScrubText(t *testing.T) {
+	email := types.ScrubRule{Pattern: `[\w.]+@[\w.]+`}
+	card := types.ScrubRule{Pattern: `\d{4}( ?\d{4}){3}`}
+	tests := []struct {
+		name    string
+		rules   []types.ScrubRule
+		value   string
+		want    string
+		matches int
+	}{
+		{"no match", []types.ScrubRule{email}, "hello", "hello", 0},
+		{"whole value", []types.ScrubRule{email}, "a.b@example.com", mask, 1},
+		{"several matches", []types.ScrubRule{email}, "from a@b.c to d@e.f", "from *** to ***", 2},
+		{"several patterns", []types.ScrubRule{email, card}, "a@b.c 4111 1111 1111 1111", "*** ***", 2},
+		{"broken pattern is skipped", []types.ScrubRule{{Pattern: "("}, email}, "a@b.c", mask, 1},
+		{"path only rule", []types.ScrubRule{{JSONPath: "$.email"}}, "a@b.c", "a@b.c", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scrubber{mask: mask}
+			rules := s.getRules(&types.Project{ProjectID: 1, ScrubRules: tt.rules})
+			got := tt.value
+			matches := s.scrubText(rules, &got)
+			if got != tt.want || matches != tt.matches {
+				t.Errorf("got %q with %d matches, want %q with %d", got, matches, tt.want, tt.matches)
+			}
+		})
+	}
+}
+
+func testBatch(msgs ...Message) []byte {
+	batch := Encode(&BatchMetadata{Version: 1, PageNo: 1, FirstIndex: 1, Timestamp: 1600000000000})
+	for _, msg := range msgs {
+		batch = append(batch, EncodeSized(msg)...)
+	}
+	return batch
+}
+
+func TestScrubBatch(t *testing.T) {
+	s := &Scrubber{mask: mask}
+	p := &types.Project{ProjectID: 1, ScrubRules: []types.ScrubRule{
+		{Pattern: `[\w.]+@[\w.]+`},
+		{JSONPath: "$.password"},
+	}}
+	batch := testBatch(
+		&SetInputValue{ID: 1, Value: "a@b.c", Mask: 0},
+		&SetNodeAttribute{ID: 1, Name: "title", Value: "a@b.c"}, // Not scrubbed
+		&Fetch{Method: "POST", URL: "/login", Request: `{"login":"a@b.c","password":"p"}`, Response: "{}", Status: 200},
+	)
+	scrubbed, err := s.ScrubBatch(context.Background(), p, batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := testBatch(
+		&SetInputValue{ID: 1, Value: mask, Mask: 0},
+		&SetNodeAttribute{ID: 1, Name: "title", Value: "a@b.c"},
+		&Fetch{Method: "POST", URL: "/login", Request: `{"login":"***","password":"***"}`, Response: "{}", Status: 200},
+	)
+	if !bytes.Equal(scrubbed, want) {
+		t.Errorf("got batch %v, want %v", scrubbed, want)
+	}
+
+	// Rules are compiled again for the reloaded project
+	reloaded := &types.Project{ProjectID: 1}
+	reloaded.ScrubRules = []types.ScrubRule{{Pattern: "title"}}
+	scrubbed, err = s.ScrubBatch(context.Background(), reloaded, batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(scrubbed, batch) {
+		t.Errorf("old rules are used for the reloaded project")
+	}
+}
+
+func TestScrubBatchErrors(t *testing.T) {
+	s := &Scrubber{mask: mask}
+	batch := testBatch(&SetInputValue{ID: 1, Value: "a@b.c"})
+	rules := []types.ScrubRule{{Pattern: "@"}}
+	tests := []struct {
+		name    string
+		project *types.Project
+		batch   []byte
+		same    bool // the original data is returned
+		wantErr bool
+	}{
+		{"no project", nil, batch, false, true},
+		{"no rules", &types.Project{ProjectID: 1}, batch, true, false},
+		{"only broken rules", &types.Project{ProjectID: 2, ScrubRules: []types.ScrubRule{{Pattern: "("}}}, batch, true, false},
+		{"nothing to mask", &types.Project{ProjectID: 3, ScrubRules: []types.ScrubRule{{Pattern: "password"}}}, batch, true, false},
+		{"truncated batch", &types.Project{ProjectID: 4, ScrubRules: rules}, batch[:len(batch)-1], false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scrubbed, err := s.ScrubBatch(context.Background(), tt.project, tt.batch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if tt.same && !bytes.Equal(scrubbed, tt.batch) {
+				t.Errorf("batch is changed")
+			}
+		})
+	}
+}
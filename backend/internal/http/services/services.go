@@ -3,9 +3,13 @@ package services
 import (
 	"openreplay/backend/internal/config/http"
 	"openreplay/backend/internal/http/geoip"
+	"openreplay/backend/internal/http/ratelimit"
+	"openreplay/backend/internal/http/screenshots"
+	"openreplay/backend/internal/http/scrubber"
 	"openreplay/backend/internal/http/uaparser"
 	"openreplay/backend/pkg/db/cache"
 	"openreplay/backend/pkg/flakeid"
+	"openreplay/backend/pkg/monitoring"
 	"openreplay/backend/pkg/queue/types"
 	"openreplay/backend/pkg/storage"
 	"openreplay/backend/pkg/token"
@@ -18,17 +22,21 @@ type ServicesBuilder struct {
 	UaParser  *uaparser.UAParser
 	GeoIP     *geoip.GeoIP
 	Tokenizer *token.Tokenizer
-	Storage   *storage.S3
+	Images    *screenshots.Storage
+	Limiter   *ratelimit.Limiter
+	Scrubber  *scrubber.Scrubber
 }
 
-func New(cfg *http.Config, producer types.Producer, pgconn *cache.PGCache) *ServicesBuilder {
+func New(cfg *http.Config, producer types.Producer, pgconn *cache.PGCache, metrics *monitoring.Metrics) *ServicesBuilder {
 	return &ServicesBuilder{
 		Database:  pgconn,
 		Producer:  producer,
-		Storage:   storage.NewS3(cfg.AWSRegion, cfg.S3BucketIOSImages),
-		Tokenizer: token.NewTokenizer(cfg.TokenSecret),
+		Images:    screenshots.New(storage.NewS3(cfg.AWSRegion, cfg.S3BucketIOSImages), cfg.ImagesHashListTTL),
+		Tokenizer: token.NewTokenizer(cfg.TokenSecrets, cfg.TokenSecret, cfg.TokenProjectID, metrics),
 		UaParser:  uaparser.NewUAParser(cfg.UAParserFile),
-		GeoIP:     geoip.NewGeoIP(cfg.MaxMinDBFile),
+		GeoIP:     geoip.NewGeoIP(cfg.MaxMinDBFile, cfg.MaxMindASNFile),
 		Flaker:    flakeid.NewFlaker(cfg.WorkerID),
+		Limiter:   ratelimit.New(cfg, metrics),
+		Scrubber:  scrubber.New(cfg.ScrubMask, metrics),
 	}
 }
@@ -0,0 +1,357 @@
+package spool
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+
+	"openreplay/backend/internal/config/http"
+	"openreplay/backend/pkg/monitoring"
+	"openreplay/backend/pkg/queue/types"
+)
+
+// ErrFull is returned when the batch can be neither sent to the queue nor saved to the disk
+var ErrFull = errors.New("queue is unavailable and spool is full")
+
+const (
+	fileExt = ".batch"
+	tmpExt  = ".tmp"
+	// Number of batches re-produced at once, so the spool drains faster than new batches come
+	replayConcurrency = 16
+)
+
+type entry struct {
+	name      string
+	size      int64
+	createdAt time.Time
+}
+
+// Spool is a producer which saves batches to the disk if the queue is unavailable and re-produces them later.
+// The producer returns an error from Produce if it can't accept the batch and reports accepted batches which
+// certainly weren't delivered with onFailed (see queue.NewReportingProducer). Batches which might be delivered
+// aren't spooled, so replay doesn't duplicate them.
+// After the first error new batches are saved to the spool without trying the queue, the first successful
+// replay sends them to the queue again. Spooled batches are replayed concurrently with the live ones,
+// so they may reach consumers after newer batches of the same session.
+type Spool struct {
+	producer types.Producer
+	dir      string
+	maxSize  int64
+	interval time.Duration
+	healthy  int32 // 1 if batches go to the queue directly
+	mutex    sync.Mutex
+	entries  []*entry
+	size     int64
+	seq      uint64
+	done     chan struct{}
+	stopped  chan struct{}
+	spilled  syncfloat64.Counter
+	replayed syncfloat64.Counter
+}
+
+// New creates the spool and its producer
+func New(cfg *http.Config, newProducer func(onFailed types.DeliveryFailureHandler) types.Producer, metrics *monitoring.Metrics) *Spool {
+	s := &Spool{
+		dir:      cfg.SpoolDir,
+		maxSize:  cfg.SpoolSizeLimit,
+		interval: cfg.SpoolReplayInterval,
+		healthy:  1,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		log.Fatalf("can't create spool dir: %s", err)
+	}
+	if err := s.load(); err != nil {
+		log.Fatalf("can't load spool: %s", err)
+	}
+	s.initMetrics(metrics)
+	s.producer = newProducer(s.failed)
+	go s.run()
+	return s
+}
+
+func (s *Spool) initMetrics(metrics *monitoring.Metrics) {
+	if metrics == nil {
+		return
+	}
+	var err error
+	s.spilled, err = metrics.RegisterCounter("spool_spilled")
+	if err != nil {
+		log.Printf("can't create spool_spilled metric: %s", err)
+	}
+	s.replayed, err = metrics.RegisterCounter("spool_replayed")
+	if err != nil {
+		log.Printf("can't create spool_replayed metric: %s", err)
+	}
+	if err := metrics.RegisterGauge("spool_depth", s.depth); err != nil {
+		log.Printf("can't create spool_depth metric: %s", err)
+	}
+	if err := metrics.RegisterGauge("spool_size", s.bytes); err != nil {
+		log.Printf("can't create spool_size metric: %s", err)
+	}
+	if err := metrics.RegisterGauge("spool_age", s.age); err != nil {
+		log.Printf("can't create spool_age metric: %s", err)
+	}
+}
+
+// load picks up batches left by the previous run
+func (s *Spool) load() error {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		name := file.Name()
+		if strings.HasSuffix(name, tmpExt) {
+			os.Remove(filepath.Join(s.dir, name))
+			continue
+		}
+		if !strings.HasSuffix(name, fileExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, fileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			return err
+		}
+		if seq > s.seq {
+			s.seq = seq
+		}
+		s.entries = append(s.entries, &entry{name: name, size: info.Size(), createdAt: info.ModTime()})
+		s.size += info.Size()
+	}
+	// Names are zero padded, so the order is the same as the order of sequence numbers
+	sort.Slice(s.entries, func(i, j int) bool { return s.entries[i].name < s.entries[j].name })
+	if len(s.entries) > 0 {
+		log.Printf("loaded %d batches from spool", len(s.entries))
+	}
+	return nil
+}
+
+func (s *Spool) isHealthy() bool {
+	return atomic.LoadInt32(&s.healthy) == 1
+}
+
+func (s *Spool) setHealthy(healthy bool) {
+	var value int32
+	if healthy {
+		value = 1
+	}
+	if atomic.SwapInt32(&s.healthy, value) != value {
+		log.Printf("queue is available: %t", healthy)
+	}
+}
+
+func (s *Spool) Produce(topic string, key uint64, value []byte) error {
+	if s.isHealthy() {
+		err := s.producer.Produce(topic, key, value)
+		if err == nil {
+			return nil
+		}
+		s.setHealthy(false)
+		log.Printf("can't send batch to queue, saving to spool: %s", err)
+	}
+	return s.spill(topic, key, value)
+}
+
+func (s *Spool) ProduceToPartition(topic string, partition, key uint64, value []byte) error {
+	return s.producer.ProduceToPartition(topic, partition, key, value)
+}
+
+// failed saves the batch the producer couldn't deliver after accepting it
+func (s *Spool) failed(topic string, key uint64, value []byte) {
+	if err := s.spill(topic, key, value); err != nil {
+		log.Printf("undelivered batch is lost, topic: %s, sessID: %d: %s", topic, key, err)
+	}
+}
+
+// spill writes the batch as: topic length (2 bytes), topic, key (8 bytes), value
+func (s *Spool) spill(topic string, key uint64, value []byte) error {
+	data := make([]byte, 2+len(topic)+8+len(value))
+	binary.LittleEndian.PutUint16(data, uint16(len(topic)))
+	copy(data[2:], topic)
+	binary.LittleEndian.PutUint64(data[2+len(topic):], key)
+	copy(data[10+len(topic):], value)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.size+int64(len(data)) > s.maxSize {
+		return ErrFull
+	}
+	s.seq++
+	name := fmt.Sprintf("%020d%s", s.seq, fileExt)
+	path := filepath.Join(s.dir, name)
+	if err := os.WriteFile(path+tmpExt, data, 0644); err != nil {
+		log.Printf("can't write batch to spool: %s", err)
+		return ErrFull
+	}
+	if err := os.Rename(path+tmpExt, path); err != nil {
+		log.Printf("can't write batch to spool: %s", err)
+		return ErrFull
+	}
+	s.entries = append(s.entries, &entry{name: name, size: int64(len(data)), createdAt: time.Now()})
+	s.size += int64(len(data))
+	if s.spilled != nil {
+		s.spilled.Add(context.Background(), 1)
+	}
+	return nil
+}
+
+func (s *Spool) run() {
+	defer close(s.stopped)
+	tick := time.NewTicker(s.interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-tick.C:
+			s.replay()
+		}
+	}
+}
+
+// replay re-produces batches from the oldest ones, replayConcurrency at once, and stops on the first queue error
+func (s *Spool) replay() {
+	for {
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+		s.mutex.Lock()
+		round := s.entries
+		if len(round) > replayConcurrency {
+			round = round[:replayConcurrency]
+		}
+		round = append([]*entry(nil), round...)
+		s.mutex.Unlock()
+		if len(round) == 0 {
+			return
+		}
+
+		finished := make([]bool, len(round))
+		var wg sync.WaitGroup
+		for i, e := range round {
+			wg.Add(1)
+			go func(i int, e *entry) {
+				defer wg.Done()
+				finished[i] = s.replayEntry(e)
+			}(i, e)
+		}
+		wg.Wait()
+
+		failed := s.remove(round, finished)
+		if failed {
+			return
+		}
+		s.setHealthy(true)
+	}
+}
+
+// replayEntry re-produces the batch and removes its file, it returns false if the batch has to stay in the spool
+func (s *Spool) replayEntry(e *entry) bool {
+	path := filepath.Join(s.dir, e.name)
+	topic, key, value, err := readBatch(path)
+	if err != nil {
+		log.Printf("dropping broken batch %s from spool: %s", e.name, err)
+	} else if err := s.producer.Produce(topic, key, value); err != nil {
+		log.Printf("can't replay batch from spool: %s", err)
+		return false
+	} else if s.replayed != nil {
+		s.replayed.Add(context.Background(), 1)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.Printf("can't remove batch from spool: %s", err)
+	}
+	return true
+}
+
+// remove deletes finished entries of the round and returns true if some of them are left
+func (s *Spool) remove(round []*entry, finished []bool) bool {
+	done := make(map[*entry]bool, len(round))
+	for i, e := range round {
+		if finished[i] {
+			done[e] = true
+		}
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// Only replay removes entries, new ones are added to the end
+	entries := s.entries[:0]
+	for _, e := range s.entries {
+		if done[e] {
+			s.size -= e.size
+			continue
+		}
+		entries = append(entries, e)
+	}
+	s.entries = entries
+	return len(done) < len(round)
+}
+
+func readBatch(path string) (string, uint64, []byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if len(data) < 2 {
+		return "", 0, nil, errors.New("batch is too short")
+	}
+	topicLen := int(binary.LittleEndian.Uint16(data))
+	if len(data) < 2+topicLen+8 {
+		return "", 0, nil, errors.New("batch is too short")
+	}
+	topic := string(data[2 : 2+topicLen])
+	key := binary.LittleEndian.Uint64(data[2+topicLen:])
+	return topic, key, data[10+topicLen:], nil
+}
+
+func (s *Spool) depth() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return float64(len(s.entries))
+}
+
+func (s *Spool) bytes() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return float64(s.size)
+}
+
+// age returns the age of the oldest batch in seconds
+func (s *Spool) age() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.entries) == 0 {
+		return 0
+	}
+	return time.Since(s.entries[0].createdAt).Seconds()
+}
+
+func (s *Spool) Flush(timeout int) {
+	s.producer.Flush(timeout)
+}
+
+// Close stops the replayer, remaining batches stay on the disk until the next start
+func (s *Spool) Close(timeout int) {
+	close(s.done)
+	<-s.stopped
+	s.producer.Close(timeout)
+}
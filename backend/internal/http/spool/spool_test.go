@@ -0,0 +1,170 @@
+package spool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"openreplay/backend/internal/config/http"
+	"openreplay/backend/pkg/queue/types"
+)
+
+// testProducer keeps produced batches, it fails while broken is set
+type testProducer struct {
+	mutex    sync.Mutex
+	broken   bool
+	produced map[string]bool
+	onFailed types.DeliveryFailureHandler
+}
+
+func newTestProducer(broken bool) *testProducer {
+	return &testProducer{broken: broken, produced: make(map[string]bool)}
+}
+
+func (p *testProducer) setBroken(broken bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.broken = broken
+}
+
+func (p *testProducer) count() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return len(p.produced)
+}
+
+func (p *testProducer) Produce(topic string, key uint64, value []byte) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.broken {
+		return errors.New("queue is down")
+	}
+	p.produced[fmt.Sprintf("%s/%d/%s", topic, key, value)] = true
+	return nil
+}
+
+func (p *testProducer) ProduceToPartition(topic string, _, key uint64, value []byte) error {
+	return p.Produce(topic, key, value)
+}
+
+func (p *testProducer) Close(int) {}
+func (p *testProducer) Flush(int) {}
+
+func testFactory(p *testProducer) func(types.DeliveryFailureHandler) types.Producer {
+	return func(onFailed types.DeliveryFailureHandler) types.Producer {
+		p.onFailed = onFailed
+		return p
+	}
+}
+
+func testConfig(t *testing.T, sizeLimit int64) *http.Config {
+	cfg := &http.Config{}
+	cfg.SpoolDir = t.TempDir()
+	cfg.SpoolSizeLimit = sizeLimit
+	cfg.SpoolReplayInterval = 10 * time.Millisecond
+	return cfg
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSpoolHealthyQueue(t *testing.T) {
+	producer := newTestProducer(false)
+	s := New(testConfig(t, 1<<20), testFactory(producer), nil)
+	defer s.Close(0)
+	for i := 0; i < 10; i++ {
+		if err := s.Produce("raw", uint64(i), []byte("batch")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if producer.count() != 10 || s.depth() != 0 {
+		t.Errorf("produced %d, spooled %.0f", producer.count(), s.depth())
+	}
+}
+
+func TestSpoolReplay(t *testing.T) {
+	producer := newTestProducer(true)
+	s := New(testConfig(t, 1<<20), testFactory(producer), nil)
+	defer s.Close(0)
+	const total = 100
+	for i := 0; i < total; i++ {
+		if err := s.Produce("raw", uint64(i), []byte(fmt.Sprintf("batch %d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.depth() != total || s.isHealthy() {
+		t.Fatalf("spooled %.0f, healthy %t", s.depth(), s.isHealthy())
+	}
+
+	producer.setBroken(false)
+	waitFor(t, "replay", func() bool { return s.depth() == 0 })
+	if producer.count() != total {
+		t.Errorf("replayed %d of %d batches", producer.count(), total)
+	}
+	if s.bytes() != 0 {
+		t.Errorf("spool size is %.0f after replay", s.bytes())
+	}
+
+	// Live batches go to the queue directly after the replay
+	waitFor(t, "healthy queue", s.isHealthy)
+	if err := s.Produce("raw", 1000, []byte("live")); err != nil {
+		t.Fatal(err)
+	}
+	if producer.count() != total+1 || s.depth() != 0 {
+		t.Errorf("live batch isn't produced directly")
+	}
+}
+
+func TestSpoolDeliveryFailure(t *testing.T) {
+	producer := newTestProducer(false)
+	s := New(testConfig(t, 1<<20), testFactory(producer), nil)
+	defer s.Close(0)
+	// The batch was accepted by the producer, but never delivered
+	producer.onFailed("raw", 1, []byte("lost"))
+	if s.depth() != 1 {
+		t.Fatalf("undelivered batch isn't spooled")
+	}
+	waitFor(t, "replay", func() bool { return s.depth() == 0 })
+	if producer.count() != 1 {
+		t.Errorf("undelivered batch isn't replayed")
+	}
+}
+
+func TestSpoolFull(t *testing.T) {
+	producer := newTestProducer(true)
+	s := New(testConfig(t, 100), testFactory(producer), nil)
+	defer s.Close(0)
+	if err := s.Produce("raw", 1, make([]byte, 50)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Produce("raw", 2, make([]byte, 50)); err != ErrFull {
+		t.Errorf("got %v, want ErrFull", err)
+	}
+}
+
+func TestSpoolRestart(t *testing.T) {
+	cfg := testConfig(t, 1<<20)
+	s := New(cfg, testFactory(newTestProducer(true)), nil)
+	for i := 0; i < 5; i++ {
+		if err := s.Produce("raw", uint64(i), []byte("batch")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Close(0)
+
+	producer := newTestProducer(false)
+	s = New(cfg, testFactory(producer), nil)
+	defer s.Close(0)
+	waitFor(t, "replay after restart", func() bool { return producer.count() == 5 })
+	waitFor(t, "empty spool", func() bool { return s.depth() == 0 })
+}
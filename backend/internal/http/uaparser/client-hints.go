@@ -0,0 +1,233 @@
+package uaparser
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// AcceptCH lists the User-Agent Client Hints the tracker should send on the next requests
+const AcceptCH = "Sec-CH-UA, Sec-CH-UA-Mobile, Sec-CH-UA-Platform, Sec-CH-UA-Platform-Version, Sec-CH-UA-Model, Sec-CH-UA-Full-Version-List"
+
+// Platform names in the format of uap-go
+var platforms = map[string]string{
+	"windows":   "Windows",
+	"macos":     "Mac OS X",
+	"android":   "Android",
+	"ios":       "iOS",
+	"linux":     "Linux",
+	"chrome os": "Chrome OS",
+	"chromeos":  "Chrome OS",
+}
+
+// Browser brands in the format of uap-go
+var brands = map[string]string{
+	"google chrome":    "Chrome",
+	"microsoft edge":   "Edge",
+	"opera":            "Opera",
+	"yandex":           "Yandex Browser",
+	"samsung internet": "Samsung Internet",
+}
+
+type brand struct {
+	name    string
+	version string
+}
+
+// applyClientHints overrides values parsed from the frozen User-Agent string by client hints if they are present
+func applyClientHints(ua *UA, header http.Header) {
+	if b := mainBrand(header.Get("Sec-CH-UA-Full-Version-List")); b != nil {
+		ua.Browser, ua.BrowserVersion = b.name, b.version
+	} else if b := mainBrand(header.Get("Sec-CH-UA")); b != nil {
+		ua.Browser = b.name
+		if !strings.HasPrefix(ua.BrowserVersion, b.version) {
+			ua.BrowserVersion = b.version
+		}
+	}
+
+	if platform := unquote(header.Get("Sec-CH-UA-Platform")); platform != "" {
+		if name, ok := platforms[strings.ToLower(platform)]; ok {
+			ua.OS = name
+		} else {
+			ua.OS = platform
+		}
+	}
+	if version := unquote(header.Get("Sec-CH-UA-Platform-Version")); version != "" {
+		ua.OSVersion = platformVersion(ua.OS, version)
+	}
+
+	if model := unquote(header.Get("Sec-CH-UA-Model")); model != "" {
+		ua.Device = model
+	}
+	switch header.Get("Sec-CH-UA-Mobile") {
+	case "?1":
+		ua.DeviceType = "mobile"
+	case "?0":
+		if ua.DeviceType == "mobile" {
+			ua.DeviceType = "desktop"
+		}
+	}
+}
+
+// mainBrand returns the most specific brand of the list like `"Chromium";v="110", "Google Chrome";v="110"`
+func mainBrand(list string) *brand {
+	var res *brand
+	for _, b := range parseBrandList(list) {
+		if b.name == "" || isGreaseBrand(b.name) {
+			continue
+		}
+		if mapped, ok := brands[strings.ToLower(b.name)]; ok {
+			b.name = mapped
+		}
+		// Chromium is the engine of the most of browsers, so any other brand is more specific
+		if res == nil || res.name == "Chromium" {
+			res = b
+		}
+	}
+	return res
+}
+
+// isGreaseBrand checks for the fake brands browsers add to prevent sniffing (e.g. " Not A;Brand" or "Not_A Brand")
+func isGreaseBrand(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "not") && strings.Contains(name, "brand")
+}
+
+// parseBrandList parses the brand list as a structured field list (RFC 8941), so quoted names may contain
+// separators. Brand names are strings or tokens, the version is the "v" parameter. Nil is returned for a broken list.
+func parseBrandList(list string) []*brand {
+	p := &sfParser{s: list}
+	p.skipSpaces()
+	var res []*brand
+	for p.i < len(p.s) {
+		b := &brand{}
+		name, ok := p.bareItem()
+		if !ok {
+			return nil
+		}
+		b.name = name
+		for p.i < len(p.s) && p.s[p.i] == ';' {
+			p.i++
+			p.skipSpaces()
+			key := p.key()
+			if key == "" {
+				return nil
+			}
+			value := ""
+			if p.i < len(p.s) && p.s[p.i] == '=' {
+				p.i++
+				if value, ok = p.bareItem(); !ok {
+					return nil
+				}
+			}
+			if key == "v" {
+				b.version = value
+			}
+		}
+		res = append(res, b)
+		p.skipSpaces()
+		if p.i == len(p.s) {
+			break
+		}
+		if p.s[p.i] != ',' {
+			return nil
+		}
+		p.i++
+		p.skipSpaces()
+		if p.i == len(p.s) {
+			return nil // Trailing comma
+		}
+	}
+	return res
+}
+
+type sfParser struct {
+	s string
+	i int
+}
+
+func (p *sfParser) skipSpaces() {
+	for p.i < len(p.s) && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+// bareItem reads a string, a token or a number (RFC 8941 3.3) and returns its text
+func (p *sfParser) bareItem() (string, bool) {
+	if p.i == len(p.s) {
+		return "", false
+	}
+	if p.s[p.i] == '"' {
+		return p.quotedString()
+	}
+	start := p.i
+	for p.i < len(p.s) && isTokenChar(p.s[p.i]) {
+		p.i++
+	}
+	return p.s[start:p.i], p.i > start
+}
+
+func (p *sfParser) quotedString() (string, bool) {
+	var sb strings.Builder
+	for p.i++; p.i < len(p.s); p.i++ {
+		switch c := p.s[p.i]; c {
+		case '\\':
+			p.i++
+			if p.i == len(p.s) || (p.s[p.i] != '"' && p.s[p.i] != '\\') {
+				return "", false
+			}
+			sb.WriteByte(p.s[p.i])
+		case '"':
+			p.i++
+			return sb.String(), true
+		default:
+			if c < 0x20 || c > 0x7e {
+				return "", false
+			}
+			sb.WriteByte(c)
+		}
+	}
+	return "", false // No closing quote
+}
+
+// key reads a parameter key, it starts with a lowercase letter or "*"
+func (p *sfParser) key() string {
+	start := p.i
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if !(c >= 'a' && c <= 'z' || c == '*' || p.i > start && (c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.')) {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+// isTokenChar accepts characters of tokens and numbers (tchar, ":" and "/")
+func isTokenChar(c byte) bool {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+		return true
+	}
+	return strings.IndexByte("!#$%&'*+-.^_`|~:/", c) >= 0
+}
+
+func platformVersion(os string, version string) string {
+	if os != "Windows" {
+		return version
+	}
+	// Windows platform version is the version of Universal API Contract, 13+ means Windows 11
+	major, err := strconv.Atoi(strings.Split(version, ".")[0])
+	switch {
+	case err != nil:
+		return version
+	case major >= 13:
+		return "11"
+	case major > 0:
+		return "10"
+	}
+	return "8.1" // Windows 7, 8 and 8.1 have zero platform version, the latest one is the most probable
+}
+
+func unquote(value string) string {
+	return strings.Trim(strings.TrimSpace(value), `"`)
+}
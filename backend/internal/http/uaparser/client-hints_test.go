@@ -0,0 +1,138 @@
+package uaparser
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseBrandList(t *testing.T) {
+	tests := []struct {
+		name string
+		list string
+		want []brand
+	}{
+		{"empty", "", nil},
+		{"single", `"Chromium";v="110"`, []brand{{"Chromium", "110"}}},
+		{"full version list", `"Chromium";v="110.0.5481.100", "Google Chrome";v="110.0.5481.100"`,
+			[]brand{{"Chromium", "110.0.5481.100"}, {"Google Chrome", "110.0.5481.100"}}},
+		{"grease with separators", `"Not A(Brand";v="24", "Chromium";v="110"`, []brand{{"Not A(Brand", "24"}, {"Chromium", "110"}}},
+		{"comma and semicolon in name", `"A, B;C";v="1", "Opera";v="95"`, []brand{{"A, B;C", "1"}, {"Opera", "95"}}},
+		{"escaped quote", `"Brand \"X\" \\";v="1"`, []brand{{`Brand "X" \`, "1"}}},
+		{"token name and version", `Chromium;v=110`, []brand{{"Chromium", "110"}}},
+		{"no version", `"Chromium"`, []brand{{"Chromium", ""}}},
+		{"other parameters", `"Chromium";a;v="110";b=1`, []brand{{"Chromium", "110"}}},
+		{"spaces and tabs", " \t\"A\";v=\"1\" ,\t\"B\";v=\"2\" ", []brand{{"A", "1"}, {"B", "2"}}},
+		{"trailing comma", `"Chromium";v="110",`, nil},
+		{"no closing quote", `"Chromium;v="110"`, nil},
+		{"missing comma", `"A";v="1" "B";v="2"`, nil},
+		{"uppercase key", `"Chromium";V="110"`, nil},
+		{"empty parameter value", `"Chromium";v=`, nil},
+		{"bad escape", `"Chro\mium"`, nil},
+		{"non-ASCII", "\"Chrömium\"", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []brand
+			for _, b := range parseBrandList(tt.list) {
+				got = append(got, *b)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMainBrand(t *testing.T) {
+	tests := []struct {
+		name string
+		list string
+		want *brand
+	}{
+		{"chrome", `" Not A;Brand";v="99", "Chromium";v="110", "Google Chrome";v="110"`, &brand{"Chrome", "110"}},
+		{"chrome first", `"Google Chrome";v="110", "Not_A Brand";v="8", "Chromium";v="110"`, &brand{"Chrome", "110"}},
+		{"edge", `"Chromium";v="110", "Not A(Brand";v="24", "Microsoft Edge";v="110"`, &brand{"Edge", "110"}},
+		{"only chromium", `"Chromium";v="110", "Not A(Brand";v="24"`, &brand{"Chromium", "110"}},
+		{"unknown brand", `"Chromium";v="110", "Vivaldi";v="5"`, &brand{"Vivaldi", "5"}},
+		{"only grease", `"Not A(Brand";v="24"`, nil},
+		{"broken list", `"Chromium";v="110`, nil},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mainBrand(tt.list); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyClientHints(t *testing.T) {
+	// Frozen User-Agent string of Chrome on Windows
+	frozen := UA{OS: "Windows", OSVersion: "10", Browser: "Chrome", BrowserVersion: "110.0.0.0", DeviceType: "desktop"}
+	tests := []struct {
+		name   string
+		ua     UA
+		header map[string]string
+		want   UA
+	}{
+		{"no hints", frozen, nil, frozen},
+		{"full version", frozen, map[string]string{
+			"Sec-CH-UA":                   `"Chromium";v="110", "Microsoft Edge";v="110"`,
+			"Sec-CH-UA-Full-Version-List": `"Chromium";v="110.0.5481.100", "Microsoft Edge";v="110.0.1587.50"`,
+		}, UA{OS: "Windows", OSVersion: "10", Browser: "Edge", BrowserVersion: "110.0.1587.50", DeviceType: "desktop"}},
+		{"major version keeps the frozen one", frozen, map[string]string{
+			"Sec-CH-UA": `"Chromium";v="110", "Google Chrome";v="110"`,
+		}, frozen},
+		{"major version differs", frozen, map[string]string{
+			"Sec-CH-UA": `"Chromium";v="111", "Google Chrome";v="111"`,
+		}, UA{OS: "Windows", OSVersion: "10", Browser: "Chrome", BrowserVersion: "111", DeviceType: "desktop"}},
+		{"windows 11", frozen, map[string]string{
+			"Sec-CH-UA-Platform":         `"Windows"`,
+			"Sec-CH-UA-Platform-Version": `"15.0.0"`,
+		}, UA{OS: "Windows", OSVersion: "11", Browser: "Chrome", BrowserVersion: "110.0.0.0", DeviceType: "desktop"}},
+		{"mobile", UA{OS: "Linux", Browser: "Chrome", BrowserVersion: "110.0.0.0", DeviceType: "desktop"}, map[string]string{
+			"Sec-CH-UA-Platform":         `"Android"`,
+			"Sec-CH-UA-Platform-Version": `"13.0.0"`,
+			"Sec-CH-UA-Model":            `"Pixel 7"`,
+			"Sec-CH-UA-Mobile":           "?1",
+		}, UA{OS: "Android", OSVersion: "13.0.0", Browser: "Chrome", BrowserVersion: "110.0.0.0", Device: "Pixel 7", DeviceType: "mobile"}},
+		{"not mobile", UA{DeviceType: "mobile"}, map[string]string{"Sec-CH-UA-Mobile": "?0"}, UA{DeviceType: "desktop"}},
+		{"unknown platform", UA{}, map[string]string{"Sec-CH-UA-Platform": `"Fuchsia"`}, UA{OS: "Fuchsia"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tt.header {
+				header.Set(k, v)
+			}
+			ua := tt.ua
+			applyClientHints(&ua, header)
+			if ua != tt.want {
+				t.Errorf("got %+v, want %+v", ua, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlatformVersion(t *testing.T) {
+	tests := []struct {
+		os      string
+		version string
+		want    string
+	}{
+		{"Windows", "15.0.0", "11"},
+		{"Windows", "13.0.0", "11"},
+		{"Windows", "10.0.0", "10"},
+		{"Windows", "1.0.0", "10"},
+		{"Windows", "0.3.0", "8.1"},
+		{"Windows", "unknown", "unknown"},
+		{"Mac OS X", "13.2.1", "13.2.1"},
+	}
+	for _, tt := range tests {
+		if got := platformVersion(tt.os, tt.version); got != tt.want {
+			t.Errorf("%s %s: got %s, want %s", tt.os, tt.version, got, tt.want)
+		}
+	}
+}
@@ -4,5 +4,9 @@ import "net/http"
 
 func (parser *UAParser) ParseFromHTTPRequest(r *http.Request) *UA {
 	str := r.Header.Get("User-Agent")
-	return parser.Parse(str)
+	ua := parser.Parse(str)
+	if ua != nil {
+		applyClientHints(ua, r.Header)
+	}
+	return ua
 }
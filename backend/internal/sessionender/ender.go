@@ -9,6 +9,9 @@ import (
 	"time"
 )
 
+// maxClockDrift is how far (ms) user's timestamp may get ahead of the broker's one after the clock skew correction
+const maxClockDrift = 60 * 1000
+
 // EndedSessionHandler handler for ended sessions
 type EndedSessionHandler func(sessionID uint64, timestamp int64) bool
 
@@ -20,10 +23,17 @@ type session struct {
 	isEnded       bool
 }
 
+// clockSkew is the difference between server's and user's clocks, only web trackers report it
+type clockSkew struct {
+	value int64 // server's time minus user's time
+	setAt int64 // local timestamp
+}
+
 // SessionEnder updates timestamp of last message for each session
 type SessionEnder struct {
 	timeout        int64
-	sessions       map[uint64]*session // map[sessionID]session
+	sessions       map[uint64]*session   // map[sessionID]session
+	clockSkews     map[uint64]*clockSkew // map[sessionID]clockSkew
 	timeCtrl       *timeController
 	activeSessions syncfloat64.UpDownCounter
 	totalSessions  syncfloat64.Counter
@@ -45,6 +55,7 @@ func New(metrics *monitoring.Metrics, timeout int64, parts int) (*SessionEnder,
 	return &SessionEnder{
 		timeout:        timeout,
 		sessions:       make(map[uint64]*session),
+		clockSkews:     make(map[uint64]*clockSkew),
 		timeCtrl:       NewTimeController(parts),
 		activeSessions: activeSessions,
 		totalSessions:  totalSessions,
@@ -60,6 +71,12 @@ func (se *SessionEnder) UpdateSession(sessionID uint64, timestamp, msgTimestamp
 		return
 	}
 	se.timeCtrl.UpdateTime(sessionID, currTS)
+	// User's timestamps from the future would stretch the session duration
+	if skew, ok := se.clockSkews[sessionID]; ok {
+		if maxUserTS := currTS - skew.value + maxClockDrift; msgTimestamp > maxUserTS {
+			msgTimestamp = maxUserTS
+		}
+	}
 	sess, ok := se.sessions[sessionID]
 	if !ok {
 		se.sessions[sessionID] = &session{
@@ -84,6 +101,11 @@ func (se *SessionEnder) UpdateSession(sessionID uint64, timestamp, msgTimestamp
 	}
 }
 
+// SetClockSkew saves the difference between server's and user's clocks measured at the session start
+func (se *SessionEnder) SetClockSkew(sessionID uint64, skew int64) {
+	se.clockSkews[sessionID] = &clockSkew{value: skew, setAt: time.Now().UnixMilli()}
+}
+
 // HandleEndedSessions runs handler for each ended session and delete information about session in successful case
 func (se *SessionEnder) HandleEndedSessions(handler EndedSessionHandler) {
 	currTime := time.Now().UnixMilli()
@@ -94,6 +116,7 @@ func (se *SessionEnder) HandleEndedSessions(handler EndedSessionHandler) {
 			sess.isEnded = true
 			if handler(sessID, sess.lastUserTime) {
 				delete(se.sessions, sessID)
+				delete(se.clockSkews, sessID)
 				se.activeSessions.Add(context.Background(), -1)
 				removedSessions++
 			} else {
@@ -101,5 +124,11 @@ func (se *SessionEnder) HandleEndedSessions(handler EndedSessionHandler) {
 			}
 		}
 	}
+	// Skew of the session without other messages (or coming after its end) is never removed with the session
+	for sessID, skew := range se.clockSkews {
+		if _, ok := se.sessions[sessID]; !ok && currTime-skew.setAt > se.timeout {
+			delete(se.clockSkews, sessID)
+		}
+	}
 	log.Printf("Removed %d of %d sessions", removedSessions, allSessions)
 }
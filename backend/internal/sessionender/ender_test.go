@@ -0,0 +1,28 @@
+package sessionender
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockSkewExpiration(t *testing.T) {
+	se := &SessionEnder{
+		timeout:    1000,
+		sessions:   make(map[uint64]*session),
+		clockSkews: make(map[uint64]*clockSkew),
+		timeCtrl:   NewTimeController(1),
+	}
+	se.SetClockSkew(1, 5000) // session without other messages
+	se.SetClockSkew(2, 5000) // recent one
+	se.SetClockSkew(3, 5000) // active session
+	se.clockSkews[1].setAt -= 2000
+	se.clockSkews[3].setAt -= 2000
+	se.sessions[3] = &session{lastUpdate: time.Now().UnixMilli()}
+
+	se.HandleEndedSessions(func(uint64, int64) bool { return false })
+	for sessID, kept := range map[uint64]bool{1: false, 2: true, 3: true} {
+		if _, ok := se.clockSkews[sessID]; ok != kept {
+			t.Errorf("skew of session %d is kept: %t, want %t", sessID, ok, kept)
+		}
+	}
+}
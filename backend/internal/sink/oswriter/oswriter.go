@@ -5,6 +5,8 @@ import (
 	"os"
 	"strconv"
 	"time"
+
+	"openreplay/backend/pkg/messages"
 )
 
 type Writer struct {
@@ -12,6 +14,7 @@ type Writer struct {
 	dir    string
 	files  map[uint64]*os.File
 	atimes map[uint64]int64
+	buf    []byte // Reused for encoding of messages
 }
 
 func NewWriter(ulimit uint16, dir string) *Writer {
@@ -76,6 +79,15 @@ func (w *Writer) Write(key uint64, data []byte) error {
 	return err
 }
 
+// WriteMessage writes the message with index to the session file and returns the number of written bytes
+func (w *Writer) WriteMessage(key uint64, msg messages.Message) (int, error) {
+	w.buf = messages.AppendWithIndex(w.buf[:0], msg)
+	if err := w.Write(key, w.buf); err != nil {
+		return 0, err
+	}
+	return len(w.buf), nil
+}
+
 func (w *Writer) SyncAll() error {
 	for _, file := range w.files {
 		if err := file.Sync(); err != nil {
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"errors"
+	. "openreplay/backend/pkg/db/types"
+	. "openreplay/backend/pkg/messages"
+)
+
+// InsertAndroidSessionStart creates the session, the rest of mobile messages are the same as iOS ones
+func (c *PGCache) InsertAndroidSessionStart(sessionID uint64, s *AndroidSessionStart) error {
+	if c.sessions[sessionID] != nil {
+		return errors.New("This session already in cache!")
+	}
+	c.sessions[sessionID] = &Session{
+		SessionID:      sessionID,
+		Platform:       "android",
+		Timestamp:      s.Timestamp,
+		ProjectID:      uint32(s.ProjectID),
+		TrackerVersion: s.TrackerVersion,
+		RevID:          s.RevID,
+		UserUUID:       s.UserUUID,
+		UserOS:         s.UserOS,
+		UserOSVersion:  s.UserOSVersion,
+		UserDevice:     s.UserDevice,
+		UserCountry:    s.UserCountry,
+		UserDeviceType: s.UserDeviceType,
+	}
+	if err := c.Conn.InsertSessionStart(sessionID, c.sessions[sessionID]); err != nil {
+		c.sessions[sessionID] = nil
+		return err
+	}
+	return nil
+}
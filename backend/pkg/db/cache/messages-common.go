@@ -4,9 +4,52 @@ import (
 	"log"
 	. "openreplay/backend/pkg/messages"
 	"time"
-	//	. "openreplay/backend/pkg/db/types"
+
+	. "openreplay/backend/pkg/db/types"
 )
 
+// geoCoordinates converts coordinates of geo messages (multiplied by 10), zero location means unknown
+func geoCoordinates(lat, lon int64) (*float64, *float64) {
+	if lat == 0 && lon == 0 {
+		return nil, nil
+	}
+	latitude, longitude := float64(lat)/10, float64(lon)/10
+	return &latitude, &longitude
+}
+
+// setSessionGeo copies the location from SessionGeo message to the session
+func setSessionGeo(s *Session, g *SessionGeo) {
+	if g == nil {
+		return
+	}
+	s.UserRegion = g.UserRegion
+	s.UserCity = g.UserCity
+	s.UserLatitude, s.UserLongitude = geoCoordinates(g.UserLatitude, g.UserLongitude)
+	s.UserTimezone = g.UserTimezone
+	s.UserASN = g.UserASN
+	s.UserASNOrg = g.UserASNOrg
+}
+
+// InsertSessionGeo completes the session with the location which is sent apart from the session start
+func (c *PGCache) InsertSessionGeo(sessionID uint64, g *SessionGeo) error {
+	session, err := c.GetSession(sessionID)
+	if err != nil {
+		return err
+	}
+	setSessionGeo(session, g)
+	return c.Conn.InsertSessionGeo(sessionID, session)
+}
+
+// InsertSessionClockSkew saves the clock skew which is sent apart from the session start
+func (c *PGCache) InsertSessionClockSkew(sessionID uint64, m *SessionClockSkew) error {
+	session, err := c.GetSession(sessionID)
+	if err != nil {
+		return err
+	}
+	session.ClockSkew = m.ClockSkew
+	return c.Conn.InsertSessionClockSkew(sessionID, m.ClockSkew)
+}
+
 func (c *PGCache) InsertSessionEnd(sessionID uint64, timestamp uint64) (uint64, error) {
 	return c.Conn.InsertSessionEnd(sessionID, timestamp)
 }
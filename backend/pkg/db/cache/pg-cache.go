@@ -21,8 +21,11 @@ type ProjectMeta struct {
 type PGCache struct {
 	*postgres.Conn
 	sessions                 map[uint64]*Session
-	projects                 map[uint32]*ProjectMeta
+	projects                 sync.Map // map[uint32]*ProjectMeta
 	projectsByKeys           sync.Map // map[string]*ProjectMeta
+	projectsBySecretKeys     sync.Map // map[string]*ProjectMeta
+	wrongSecretKeys          map[string]time.Time
+	wrongSecretKeysMutex     sync.Mutex
 	projectExpirationTimeout time.Duration
 }
 
@@ -31,7 +34,7 @@ func NewPGCache(pgConn *postgres.Conn, projectExpirationTimeoutMs int64) *PGCach
 	return &PGCache{
 		Conn:                     pgConn,
 		sessions:                 make(map[uint64]*Session),
-		projects:                 make(map[uint32]*ProjectMeta),
+		wrongSecretKeys:          make(map[string]time.Time),
 		projectExpirationTimeout: time.Duration(1000 * projectExpirationTimeoutMs),
 	}
 }
@@ -1,8 +1,18 @@
 package cache
 
 import (
-	. "openreplay/backend/pkg/db/types"
 	"time"
+
+	"github.com/jackc/pgx/v4"
+
+	"openreplay/backend/pkg/db/postgres"
+	. "openreplay/backend/pkg/db/types"
+)
+
+const (
+	// Wrong secret keys are remembered for a while, so requests with them don't hit the database
+	wrongSecretKeyTimeout = time.Minute
+	maxWrongSecretKeys    = 10000
 )
 
 func (c *PGCache) GetProjectByKey(projectKey string) (*Project, error) {
@@ -19,21 +29,74 @@ func (c *PGCache) GetProjectByKey(projectKey string) (*Project, error) {
 	if err != nil {
 		return nil, err
 	}
-	//c.projects[ p.ProjectID ] = &ProjectMeta{ p, time.Now().Add(c.projectExpirationTimeout) }
-	c.projectsByKeys.Store(projectKey, p)
+	c.projectsByKeys.Store(projectKey, &ProjectMeta{p, time.Now().Add(c.projectExpirationTimeout)})
+	return p, nil
+}
+
+func (c *PGCache) GetProjectBySecretKey(secretKey string) (*Project, error) {
+	pmInterface, found := c.projectsBySecretKeys.Load(secretKey)
+	if found {
+		if pm, ok := pmInterface.(*ProjectMeta); ok {
+			if time.Now().Before(pm.expirationTime) {
+				return pm.Project, nil
+			}
+		}
+	}
+
+	if c.isWrongSecretKey(secretKey) {
+		return nil, pgx.ErrNoRows
+	}
+
+	p, err := c.Conn.GetProjectBySecretKey(secretKey)
+	if err != nil {
+		if postgres.IsNoRowsErr(err) {
+			c.addWrongSecretKey(secretKey)
+		}
+		return nil, err
+	}
+	c.projectsBySecretKeys.Store(secretKey, &ProjectMeta{p, time.Now().Add(c.projectExpirationTimeout)})
 	return p, nil
 }
 
+func (c *PGCache) isWrongSecretKey(secretKey string) bool {
+	c.wrongSecretKeysMutex.Lock()
+	defer c.wrongSecretKeysMutex.Unlock()
+	expirationTime, found := c.wrongSecretKeys[secretKey]
+	return found && time.Now().Before(expirationTime)
+}
+
+func (c *PGCache) addWrongSecretKey(secretKey string) {
+	c.wrongSecretKeysMutex.Lock()
+	defer c.wrongSecretKeysMutex.Unlock()
+	now := time.Now()
+	if len(c.wrongSecretKeys) >= maxWrongSecretKeys {
+		for key, expirationTime := range c.wrongSecretKeys {
+			if now.After(expirationTime) {
+				delete(c.wrongSecretKeys, key)
+			}
+		}
+		// Memory is limited, the rest of keys go to the database until old ones expire
+		if len(c.wrongSecretKeys) >= maxWrongSecretKeys {
+			return
+		}
+	}
+	c.wrongSecretKeys[secretKey] = now.Add(wrongSecretKeyTimeout)
+}
+
 func (c *PGCache) GetProject(projectID uint32) (*Project, error) {
-	if c.projects[projectID] != nil &&
-		time.Now().Before(c.projects[projectID].expirationTime) {
-		return c.projects[projectID].Project, nil
+	pmInterface, found := c.projects.Load(projectID)
+	if found {
+		if pm, ok := pmInterface.(*ProjectMeta); ok {
+			if time.Now().Before(pm.expirationTime) {
+				return pm.Project, nil
+			}
+		}
 	}
+
 	p, err := c.Conn.GetProject(projectID)
 	if err != nil {
 		return nil, err
 	}
-	c.projects[projectID] = &ProjectMeta{p, time.Now().Add(c.projectExpirationTimeout)}
-	//c.projectsByKeys.Store(p.ProjectKey, c.projects[ projectID ])
+	c.projects.Store(projectID, &ProjectMeta{p, time.Now().Add(c.projectExpirationTimeout)})
 	return p, nil
 }
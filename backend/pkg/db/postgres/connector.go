@@ -75,6 +75,11 @@ func NewConn(url string, queueLimit, sizeLimit int, metrics *monitoring.Metrics)
 	return conn
 }
 
+// Ping checks connection to the database
+func (conn *Conn) Ping() error {
+	return conn.c.Ping()
+}
+
 func (conn *Conn) Close() error {
 	conn.c.Close()
 	return nil
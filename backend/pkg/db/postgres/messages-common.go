@@ -50,6 +50,23 @@ func (conn *Conn) InsertSessionStart(sessionID uint64, s *types.Session) error {
 	)
 }
 
+// InsertSessionGeo sets the location of the session, it isn't a part of the session start on any platform
+func (conn *Conn) InsertSessionGeo(sessionID uint64, s *types.Session) error {
+	return conn.c.Exec(`
+		UPDATE sessions SET
+			user_region = NULLIF($2, ''), user_city = NULLIF($3, ''), user_latitude = $4, user_longitude = $5,
+			user_timezone = NULLIF($6, ''), user_asn = NULLIF($7, 0::bigint), user_asn_org = NULLIF($8, '')
+		WHERE session_id = $1`,
+		sessionID,
+		s.UserRegion, s.UserCity, s.UserLatitude, s.UserLongitude,
+		s.UserTimezone, s.UserASN, s.UserASNOrg,
+	)
+}
+
+func (conn *Conn) InsertSessionClockSkew(sessionID uint64, clockSkew int64) error {
+	return conn.c.Exec(`UPDATE sessions SET clock_skew = NULLIF($2, 0) WHERE session_id = $1`, sessionID, clockSkew)
+}
+
 func (conn *Conn) HandleSessionStart(sessionID uint64, s *types.Session) error {
 	conn.insertAutocompleteValue(sessionID, s.ProjectID, getAutocompleteType("USEROS", s.Platform), s.UserOS)
 	conn.insertAutocompleteValue(sessionID, s.ProjectID, getAutocompleteType("USERDEVICE", s.Platform), s.UserDevice)
@@ -60,6 +77,14 @@ func (conn *Conn) HandleSessionStart(sessionID uint64, s *types.Session) error {
 	return nil
 }
 
+func (conn *Conn) InsertSessionAssociation(sessionID uint64, previousSessionID uint64) error {
+	return conn.c.Exec(`
+		INSERT INTO sessions_associations (session_id, previous_session_id) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`,
+		sessionID, previousSessionID,
+	)
+}
+
 func (conn *Conn) GetSessionDuration(sessionID uint64) (uint64, error) {
 	var dur uint64
 	if err := conn.c.QueryRow("SELECT COALESCE( duration, 0 ) FROM sessions WHERE session_id=$1", sessionID).Scan(&dur); err != nil {
@@ -18,6 +18,7 @@ type Pool interface {
 	Exec(sql string, arguments ...interface{}) error
 	SendBatch(b *pgx.Batch) pgx.BatchResults
 	Begin() (*_Tx, error)
+	Ping() error
 	Close()
 }
 
@@ -49,6 +50,17 @@ func (p *poolImpl) QueryRow(sql string, args ...interface{}) pgx.Row {
 	return res
 }
 
+func (p *poolImpl) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	conn, err := p.conn.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	return conn.Conn().Ping(ctx)
+}
+
 func (p *poolImpl) Exec(sql string, arguments ...interface{}) error {
 	start := time.Now()
 	_, err := p.conn.Exec(getTimeoutContext(), sql, arguments...)
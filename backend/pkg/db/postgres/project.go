@@ -1,38 +1,81 @@
 package postgres
 
 import (
+	"encoding/json"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+
 	. "openreplay/backend/pkg/db/types"
 )
 
+// projectColumns are loaded by all project getters, so the cached project has the same settings whatever way it was found
+const projectColumns = `
+	project_id, project_key, max_session_duration, sample_rate, save_request_payloads,
+	sample_rules, allowed_origins, COALESCE(monthly_sessions_quota, 0), capture_settings, scrub_rules,
+	metadata_1, metadata_2, metadata_3, metadata_4, metadata_5,
+	metadata_6, metadata_7, metadata_8, metadata_9, metadata_10`
+
+func scanProject(row pgx.Row) (*Project, error) {
+	p := &Project{}
+	var sampleRules, captureSettings, scrubRules []byte
+	if err := row.Scan(&p.ProjectID, &p.ProjectKey, &p.MaxSessionDuration, &p.SampleRate, &p.SaveRequestPayloads,
+		&sampleRules, &p.AllowedOrigins, &p.SessionsQuota, &captureSettings, &scrubRules,
+		&p.Metadata1, &p.Metadata2, &p.Metadata3, &p.Metadata4, &p.Metadata5,
+		&p.Metadata6, &p.Metadata7, &p.Metadata8, &p.Metadata9, &p.Metadata10); err != nil {
+		return nil, err
+	}
+	if len(sampleRules) > 0 {
+		if err := json.Unmarshal(sampleRules, &p.SampleRules); err != nil {
+			log.Printf("can't parse sample rules, projectID: %d, err: %s", p.ProjectID, err)
+		}
+	}
+	if len(captureSettings) > 0 {
+		settings := &CaptureSettings{}
+		if err := json.Unmarshal(captureSettings, settings); err != nil {
+			log.Printf("can't parse capture settings, projectID: %d, err: %s", p.ProjectID, err)
+		} else if err := settings.Validate(); err != nil {
+			log.Printf("wrong capture settings, projectID: %d, err: %s", p.ProjectID, err)
+		} else {
+			p.CaptureSettings = settings
+		}
+	}
+	if len(scrubRules) > 0 {
+		if err := json.Unmarshal(scrubRules, &p.ScrubRules); err != nil {
+			log.Printf("can't parse scrub rules, projectID: %d, err: %s", p.ProjectID, err)
+		}
+	}
+	return p, nil
+}
+
 func (conn *Conn) GetProjectByKey(projectKey string) (*Project, error) {
-	p := &Project{ProjectKey: projectKey}
-	if err := conn.c.QueryRow(`
-		SELECT max_session_duration, sample_rate, project_id
+	return scanProject(conn.c.QueryRow(`
+		SELECT `+projectColumns+`
 		FROM projects
 		WHERE project_key=$1 AND active = true
 	`,
 		projectKey,
-	).Scan(&p.MaxSessionDuration, &p.SampleRate, &p.ProjectID); err != nil {
-		return nil, err
-	}
-	return p, nil
+	))
+}
+
+// GetProjectBySecretKey authenticates server-to-server requests
+func (conn *Conn) GetProjectBySecretKey(secretKey string) (*Project, error) {
+	return scanProject(conn.c.QueryRow(`
+		SELECT `+projectColumns+`
+		FROM projects
+		WHERE secret_key=$1 AND active = true AND deleted_at IS NULL
+	`,
+		secretKey,
+	))
 }
 
 // TODO: logical separation of metadata
 func (conn *Conn) GetProject(projectID uint32) (*Project, error) {
-	p := &Project{ProjectID: projectID}
-	if err := conn.c.QueryRow(`
-		SELECT project_key, max_session_duration, save_request_payloads,
-			metadata_1, metadata_2, metadata_3, metadata_4, metadata_5,
-			metadata_6, metadata_7, metadata_8, metadata_9, metadata_10
+	return scanProject(conn.c.QueryRow(`
+		SELECT `+projectColumns+`
 		FROM projects
 		WHERE project_id=$1 AND active = true
 	`,
 		projectID,
-	).Scan(&p.ProjectKey, &p.MaxSessionDuration, &p.SaveRequestPayloads,
-		&p.Metadata1, &p.Metadata2, &p.Metadata3, &p.Metadata4, &p.Metadata5,
-		&p.Metadata6, &p.Metadata7, &p.Metadata8, &p.Metadata9, &p.Metadata10); err != nil {
-		return nil, err
-	}
-	return p, nil
+	))
 }
@@ -15,6 +15,9 @@ func (conn *Conn) GetSession(sessionID uint64) (*Session, error) {
 			duration, project_id, start_ts,
 			user_uuid, user_os, user_os_version, 
 			user_device, user_device_type, user_country,
+			COALESCE(user_region, ''), COALESCE(user_city, ''), user_latitude, user_longitude,
+			COALESCE(user_timezone, ''), COALESCE(user_asn, 0), COALESCE(user_asn_org, ''),
+			COALESCE(clock_skew, 0),
 			rev_id, tracker_version,
 			user_id, user_anonymous_id, referrer,
 			pages_count, events_count, errors_count, issue_types,
@@ -29,6 +32,9 @@ func (conn *Conn) GetSession(sessionID uint64) (*Session, error) {
 		&s.Duration, &s.ProjectID, &s.Timestamp,
 		&s.UserUUID, &s.UserOS, &userOSVersion,
 		&s.UserDevice, &s.UserDeviceType, &s.UserCountry,
+		&s.UserRegion, &s.UserCity, &s.UserLatitude, &s.UserLongitude,
+		&s.UserTimezone, &s.UserASN, &s.UserASNOrg,
+		&s.ClockSkew,
 		&revID, &s.TrackerVersion,
 		&s.UserID, &s.UserAnonymousID, &s.Referrer,
 		&s.PagesCount, &s.EventsCount, &s.ErrorsCount, &issueTypes,
@@ -51,3 +57,11 @@ func (conn *Conn) GetSession(sessionID uint64) (*Session, error) {
 	}
 	return s, nil
 }
+
+func (conn *Conn) GetSessionProjectID(sessionID uint64) (uint32, error) {
+	var projectID uint32
+	if err := conn.c.QueryRow("SELECT project_id FROM sessions WHERE session_id=$1", sessionID).Scan(&projectID); err != nil {
+		return 0, err
+	}
+	return projectID, nil
+}
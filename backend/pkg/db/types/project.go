@@ -1,6 +1,9 @@
 package types
 
-import "log"
+import (
+	"fmt"
+	"log"
+)
 
 type Project struct {
 	ProjectID           uint32
@@ -8,6 +11,11 @@ type Project struct {
 	MaxSessionDuration  int64
 	SampleRate          byte
 	SaveRequestPayloads bool
+	SampleRules         []SampleRule
+	AllowedOrigins      []string
+	SessionsQuota       int64 // Overrides default monthly sessions quota if not 0
+	ScrubRules          []ScrubRule
+	CaptureSettings     *CaptureSettings // Sent to the tracker at the session start
 	Metadata1           *string
 	Metadata2           *string
 	Metadata3           *string
@@ -20,6 +28,45 @@ type Project struct {
 	Metadata10          *string
 }
 
+// SampleRule overrides project sample rate for sessions matching all non-empty conditions
+type SampleRule struct {
+	Country        string `json:"country"`
+	URLPrefix      string `json:"urlPrefix"`
+	TrackerVersion string `json:"trackerVersion"`
+	LoggedIn       bool   `json:"loggedIn"`
+	Rate           byte   `json:"rate"`
+}
+
+// ScrubRule masks personal data in recorded values before they are stored
+type ScrubRule struct {
+	Pattern  string `json:"pattern"`  // Regular expression, matched substrings are masked
+	JSONPath string `json:"jsonPath"` // Path of the field in request/response payloads, e.g. "$.user.email"
+}
+
+// Input masking modes of the tracker
+const (
+	InputModePlain    = "plain"
+	InputModeObscured = "obscured"
+	InputModeHidden   = "hidden"
+)
+
+// CaptureSettings override tracker options, empty fields keep the values configured on the site
+type CaptureSettings struct {
+	CaptureConsole         *bool  `json:"captureConsole,omitempty"`
+	CaptureNetworkPayloads *bool  `json:"captureNetworkPayloads,omitempty"`
+	InputMode              string `json:"inputMode,omitempty"` // One of InputMode* values
+	CaptureCanvas          *bool  `json:"captureCanvas,omitempty"`
+}
+
+// Validate checks values which the tracker can't handle
+func (s *CaptureSettings) Validate() error {
+	switch s.InputMode {
+	case "", InputModePlain, InputModeObscured, InputModeHidden:
+		return nil
+	}
+	return fmt.Errorf("unknown input mode: %s", s.InputMode)
+}
+
 func (p *Project) GetMetadataNo(key string) uint {
 	if p == nil {
 		log.Printf("GetMetadataNo: Project is nil")
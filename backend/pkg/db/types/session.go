@@ -11,6 +11,14 @@ type Session struct {
 	UserOSVersion  string
 	UserDevice     string
 	UserCountry    string
+	UserRegion     string
+	UserCity       string
+	UserLatitude   *float64
+	UserLongitude  *float64
+	UserTimezone   string
+	UserASN        uint64
+	UserASNOrg     string
+	ClockSkew      int64 // server's time minus user's time, ms
 	Referrer       *string
 
 	Duration    *uint64
@@ -3,6 +3,7 @@ package failover
 import (
 	config "openreplay/backend/internal/config/storage"
 	"openreplay/backend/internal/storage"
+	"openreplay/backend/pkg/monitoring"
 )
 
 type SessionFinder interface {
@@ -16,6 +17,6 @@ type sessionFinderMock struct{}
 func (s *sessionFinderMock) Find(sessionID, timestamp uint64) {}
 func (s *sessionFinderMock) Stop()                            {}
 
-func NewSessionFinder(cfg *config.Config, stg *storage.Storage) (SessionFinder, error) {
+func NewSessionFinder(cfg *config.Config, stg *storage.Storage, metrics *monitoring.Metrics) (SessionFinder, error) {
 	return &sessionFinderMock{}, nil
 }
@@ -0,0 +1,181 @@
+package health
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// checkTimeout limits the time of all dependency checks of one readiness request
+const checkTimeout = 5 * time.Second
+
+// Check returns an error if the dependency isn't available
+type Check func() error
+
+// Health serves liveness and readiness probes of the service on the monitoring port
+type Health struct {
+	service         string
+	consumerTimeout time.Duration
+	lastConsume     int64 // unix ms of the last successful ConsumeNext, 0 if nothing was consumed yet
+	draining        int32
+	mutex           sync.RWMutex
+	checks          map[string]Check
+	optional        map[string]bool // Checks which are reported, but don't fail readiness
+}
+
+type response struct {
+	Service     string            `json:"service"`
+	Status      string            `json:"status"`
+	Draining    bool              `json:"draining"`
+	LastConsume int64             `json:"lastConsume,omitempty"` // ms since the last successful ConsumeNext
+	Checks      map[string]string `json:"checks,omitempty"`
+}
+
+// New registers /health/live and /health/ready handlers, it must be called once per service
+func New(service string, consumerTimeout time.Duration) *Health {
+	h := &Health{
+		service:         service,
+		consumerTimeout: consumerTimeout,
+		checks:          make(map[string]Check),
+		optional:        make(map[string]bool),
+	}
+	http.HandleFunc("/health/live", h.liveHandler)
+	http.HandleFunc("/health/ready", h.readyHandler)
+	return h
+}
+
+// AddCheck adds the dependency check to readiness probe
+func (h *Health) AddCheck(name string, check Check) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.checks[name] = check
+}
+
+// AddOptionalCheck adds the dependency check which is reported by readiness probe, but doesn't fail it,
+// it's used for dependencies required only by a part of the service
+func (h *Health) AddOptionalCheck(name string, check Check) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.checks[name] = check
+	h.optional[name] = true
+}
+
+func (h *Health) isOptional(name string) bool {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return h.optional[name]
+}
+
+// Consumed marks successful iteration of the queue consumer
+func (h *Health) Consumed() {
+	atomic.StoreInt64(&h.lastConsume, time.Now().UnixMilli())
+}
+
+// Drain marks the service as shutting down, readiness probe fails since this moment.
+// It blocks for wait, so the service keeps working until it's removed from the endpoints,
+// wait must be longer than the readiness probe period.
+func (h *Health) Drain(wait time.Duration) {
+	atomic.StoreInt32(&h.draining, 1)
+	log.Printf("draining %s, waiting %s before stop", h.service, wait)
+	time.Sleep(wait)
+}
+
+func (h *Health) isDraining() bool {
+	return atomic.LoadInt32(&h.draining) == 1
+}
+
+// sinceLastConsume returns the time passed since the last consumer iteration or 0 for services without consumer
+func (h *Health) sinceLastConsume() time.Duration {
+	last := atomic.LoadInt64(&h.lastConsume)
+	if last == 0 {
+		return 0
+	}
+	return time.Since(time.UnixMilli(last))
+}
+
+func (h *Health) liveHandler(w http.ResponseWriter, r *http.Request) {
+	resp := &response{
+		Service:     h.service,
+		Status:      "ok",
+		Draining:    h.isDraining(),
+		LastConsume: h.sinceLastConsume().Milliseconds(),
+	}
+	// Consumer stops during the shutdown, it isn't a reason to restart the service
+	if !resp.Draining && h.sinceLastConsume() > h.consumerTimeout {
+		resp.Status = "consumer is stuck"
+	}
+	h.writeResponse(w, resp)
+}
+
+func (h *Health) readyHandler(w http.ResponseWriter, r *http.Request) {
+	resp := &response{
+		Service:  h.service,
+		Status:   "ok",
+		Draining: h.isDraining(),
+		Checks:   h.runChecks(),
+	}
+	for name, result := range resp.Checks {
+		if result != "ok" && !h.isOptional(name) {
+			resp.Status = name + " isn't available"
+		}
+	}
+	if resp.Draining {
+		resp.Status = "draining"
+	}
+	h.writeResponse(w, resp)
+}
+
+// runChecks runs all checks concurrently and returns "ok" or the error text for each of them
+func (h *Health) runChecks() map[string]string {
+	h.mutex.RLock()
+	checks := make(map[string]Check, len(h.checks))
+	for name, check := range h.checks {
+		checks[name] = check
+	}
+	h.mutex.RUnlock()
+
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(checks))
+	statuses := make(map[string]string, len(checks))
+	for name, check := range checks {
+		statuses[name] = "timeout"
+		go func(name string, check Check) {
+			results <- result{name, check()}
+		}(name, check)
+	}
+	timer := time.NewTimer(checkTimeout)
+	defer timer.Stop()
+	for range checks {
+		select {
+		case res := <-results:
+			if res.err != nil {
+				statuses[res.name] = res.err.Error()
+			} else {
+				statuses[res.name] = "ok"
+			}
+		case <-timer.C:
+			return statuses
+		}
+	}
+	return statuses
+}
+
+func (h *Health) writeResponse(w http.ResponseWriter, resp *response) {
+	body, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("can't marshal health response: %s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(body)
+}
@@ -1,7 +1,8 @@
 package messages
 
 import (
-	"bytes"
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"strings"
@@ -10,162 +11,247 @@ import (
 type Iterator interface {
 	Next() bool       // Return true if we have next message
 	Type() int        // Return type of the next message
-	Message() Message // Return raw or decoded message
+	Message() Message // Return raw or decoded message, it is valid until the next call of Next
+	Err() error       // Return the reason why Next returned false or nil if the batch was read to the end
+	Skipped() int     // Return the number of broken messages which were skipped
 	Close()
 }
 
+// IteratorError describes the message which stopped reading of the batch
+type IteratorError struct {
+	Type   uint64
+	Offset int64  // Position of the message in the batch in bytes
+	Index  uint64 // Index of the message in the session
+	Err    error
+}
+
+func (e *IteratorError) Error() string {
+	return fmt.Sprintf("can't read message, type: %d, offset: %d, index: %d: %s", e.Type, e.Offset, e.Index, e.Err)
+}
+
+func (e *IteratorError) Unwrap() error {
+	return e.Err
+}
+
 type iteratorImpl struct {
-	data      *bytes.Reader
+	batch     []byte
+	data      *decoderReader
+	opts      DecoderOptions
+	raw       RawMessage // Reused for all size-prefixed messages of the batch
+	rawMeta   message
 	index     uint64
 	timestamp int64
 	version   uint64
 	msgType   uint64
 	msgSize   uint64
-	canSkip   bool
+	offset    int64 // Position of the message in the batch
+	bodyStart int64 // Position of the message body, type and size (if any) go before it
 	msg       Message
 	url       string
+	err       error
+	skipped   int
 }
 
 func NewIterator(data []byte) Iterator {
-	return &iteratorImpl{
-		data: bytes.NewReader(data),
-	}
+	return NewIteratorWithOptions(data, DefaultDecoderOptions)
+}
+
+// NewIteratorWithOptions returns the iterator which doesn't decode fields and messages over the limits
+func NewIteratorWithOptions(data []byte, opts DecoderOptions) Iterator {
+	i := &iteratorImpl{batch: data, opts: opts}
+	i.data = newDecoderReader(data, &i.opts)
+	return i
 }
 
 func (i *iteratorImpl) Next() bool {
-	if i.canSkip {
-		if _, err := i.data.Seek(int64(i.msgSize), io.SeekCurrent); err != nil {
-			log.Printf("seek err: %s", err)
+	if i.err != nil {
+		return false
+	}
+	for {
+		ok, broken := i.next()
+		if ok {
+			return true
+		}
+		if !broken {
 			return false
 		}
+		// The iterator is already at the start of the next message, so we can go on with it
+		log.Printf("skip broken message, type: %d, offset: %d, index: %d", i.msgType, i.offset, i.index)
+		i.skipped++
+		i.index++
+	}
+}
+
+// next reads the next message. It returns broken=true if the message can't be decoded but can be skipped.
+func (i *iteratorImpl) next() (ok bool, broken bool) {
+	if i.data.Len() == 0 {
+		return false, false
 	}
-	i.canSkip = false
+	i.offset = i.data.Size() - int64(i.data.Len())
 
 	var err error
 	i.msgType, err = ReadUint(i.data)
 	if err != nil {
-		if err == io.EOF {
-			return false
-		}
-		log.Printf("can't read message type: %s", err)
-		return false
+		i.fail(fmt.Errorf("can't read message type: %s", err))
+		return false, false
 	}
 
-	if i.version > 0 && messageHasSize(i.msgType) {
+	sized := i.version > 0 && messageHasSize(i.msgType)
+	if sized {
 		// Read message size if it is a new protocol version
 		i.msgSize, err = ReadSize(i.data)
 		if err != nil {
-			log.Printf("can't read message size: %s", err)
-			return false
+			i.fail(fmt.Errorf("can't read message size: %s", err))
+			return false, false
+		}
+		i.bodyStart = i.data.Size() - int64(i.data.Len())
+		if i.msgSize > uint64(i.data.Len()) {
+			i.fail(&SizeError{Err: ErrDataTruncated, Size: i.msgSize, Limit: uint64(i.data.Len())})
+			return false, false
+		}
+		// Body points to the batch data, so messages are neither copied nor read if consumer doesn't need them
+		start := i.data.Size() - int64(i.data.Len())
+		if _, err := i.data.Seek(int64(i.msgSize), io.SeekCurrent); err != nil {
+			i.fail(err)
+			return false, false
 		}
-		i.msg = &RawMessage{
-			tp:      i.msgType,
-			size:    i.msgSize,
-			meta:    &message{},
-			reader:  i.data,
-			skipped: &i.canSkip,
+		i.rawMeta = message{}
+		i.raw = RawMessage{
+			tp:   i.msgType,
+			size: i.msgSize,
+			body: i.batch[start : start+int64(i.msgSize)],
+			opts: &i.opts,
+			meta: &i.rawMeta,
+		}
+		i.msg = &i.raw
+		if i.opts.MaxMessageSize > 0 && i.msgSize > i.opts.MaxMessageSize {
+			log.Printf("message is too large, type: %d, size: %d", i.msgType, i.msgSize)
+			return false, true
 		}
-		i.canSkip = true
 	} else {
+		i.bodyStart = i.data.Size() - int64(i.data.Len())
 		i.msg, err = ReadMessage(i.msgType, i.data)
-		if err == io.EOF {
-			return false
-		} else if err != nil {
-			if strings.HasPrefix(err.Error(), "Unknown message code:") {
-				code := strings.TrimPrefix(err.Error(), "Unknown message code: ")
-				i.msg, err = DecodeExtraMessage(code, i.data)
-				if err != nil {
-					log.Printf("can't decode msg: %s", err)
-					return false
-				}
-			} else {
-				log.Printf("Batch Message decoding error on message with index %v, err: %s", i.index, err)
-				return false
+		if err != nil {
+			if !strings.HasPrefix(err.Error(), "Unknown message code:") {
+				i.fail(unexpectedEOF(err))
+				return false, false
+			}
+			code := strings.TrimPrefix(err.Error(), "Unknown message code: ")
+			i.msg, err = DecodeExtraMessage(code, i.data)
+			if err != nil {
+				i.fail(unexpectedEOF(err))
+				return false, false
 			}
 		}
+		if size := i.data.Size() - int64(i.data.Len()) - i.offset; i.opts.MaxMessageSize > 0 && uint64(size) > i.opts.MaxMessageSize {
+			i.fail(&SizeError{Err: ErrMessageTooLarge, Size: uint64(size), Limit: i.opts.MaxMessageSize})
+			return false, false
+		}
 		i.msg = transformDeprecated(i.msg)
 	}
 
+	// decode returns the decoded message or marks the iterator as failed
+	decode := func() Message {
+		msg := i.msg.Decode()
+		if msg == nil && !sized {
+			i.fail(errors.New("can't decode message"))
+		}
+		return msg
+	}
+	// release returns the message decoded only for the meta information to the pool
+	release := func(msg Message) {
+		if sized {
+			ReleaseMessage(msg)
+		}
+	}
+
 	// Process meta information
 	isBatchMeta := false
 	switch i.msgType {
 	case MsgBatchMetadata:
 		if i.index != 0 { // Might be several 0-0 BatchMeta in a row without an error though
-			log.Printf("Batch Metadata found at the end of the batch")
-			return false
+			i.fail(errors.New("batch metadata found at the end of the batch"))
+			return false, false
 		}
-		msg := i.msg.Decode()
+		msg := decode()
 		if msg == nil {
-			return false
+			return false, sized
 		}
 		m := msg.(*BatchMetadata)
 		i.index = m.PageNo<<32 + m.FirstIndex // 2^32  is the maximum count of messages per page (ha-ha)
 		i.timestamp = m.Timestamp
 		i.version = m.Version
-		i.url = m.Url
+		i.url = m.Location
+		release(msg)
 		isBatchMeta = true
 		if i.version > 1 {
-			log.Printf("incorrect batch version, skip current batch")
-			return false
+			i.fail(fmt.Errorf("unsupported batch version: %d", i.version))
+			return false, false
 		}
 	case MsgBatchMeta: // Is not required to be present in batch since IOS doesn't have it (though we might change it)
 		if i.index != 0 { // Might be several 0-0 BatchMeta in a row without an error though
-			log.Printf("Batch Meta found at the end of the batch")
-			return false
+			i.fail(errors.New("batch meta found at the end of the batch"))
+			return false, false
 		}
-		msg := i.msg.Decode()
+		msg := decode()
 		if msg == nil {
-			return false
+			return false, sized
 		}
 		m := msg.(*BatchMeta)
 		i.index = m.PageNo<<32 + m.FirstIndex // 2^32  is the maximum count of messages per page (ha-ha)
 		i.timestamp = m.Timestamp
+		release(msg)
 		isBatchMeta = true
 		// continue readLoop
 	case MsgIOSBatchMeta:
 		if i.index != 0 { // Might be several 0-0 BatchMeta in a row without an error though
-			log.Printf("Batch Meta found at the end of the batch")
-			return false
+			i.fail(errors.New("batch meta found at the end of the batch"))
+			return false, false
 		}
-		msg := i.msg.Decode()
+		msg := decode()
 		if msg == nil {
-			return false
+			return false, sized
 		}
 		m := msg.(*IOSBatchMeta)
 		i.index = m.FirstIndex
 		i.timestamp = int64(m.Timestamp)
+		release(msg)
 		isBatchMeta = true
 		// continue readLoop
 	case MsgTimestamp:
-		msg := i.msg.Decode()
+		msg := decode()
 		if msg == nil {
-			return false
+			return false, sized
 		}
 		m := msg.(*Timestamp)
 		i.timestamp = int64(m.Timestamp)
+		release(msg)
 		// No skipping here for making it easy to encode back the same sequence of message
 		// continue readLoop
 	case MsgSessionStart:
-		msg := i.msg.Decode()
+		msg := decode()
 		if msg == nil {
-			return false
+			return false, sized
 		}
 		m := msg.(*SessionStart)
 		i.timestamp = int64(m.Timestamp)
+		release(msg)
 	case MsgSessionEnd:
-		msg := i.msg.Decode()
+		msg := decode()
 		if msg == nil {
-			return false
+			return false, sized
 		}
 		m := msg.(*SessionEnd)
 		i.timestamp = int64(m.Timestamp)
+		release(msg)
 	case MsgSetPageLocation:
-		msg := i.msg.Decode()
+		msg := decode()
 		if msg == nil {
-			return false
+			return false, sized
 		}
 		m := msg.(*SetPageLocation)
 		i.url = m.URL
+		release(msg)
 	}
 	i.msg.Meta().Index = i.index
 	i.msg.Meta().Timestamp = i.timestamp
@@ -174,7 +260,17 @@ func (i *iteratorImpl) Next() bool {
 	if !isBatchMeta { // Without that indexes will be unique anyway, though shifted by 1 because BatchMeta is not counted in tracker
 		i.index++
 	}
-	return true
+	return true, false
+}
+
+// fail stops the iteration with the error about the current message
+func (i *iteratorImpl) fail(err error) {
+	i.err = &IteratorError{
+		Type:   i.msgType,
+		Offset: i.offset,
+		Index:  i.index,
+		Err:    err,
+	}
 }
 
 func (i *iteratorImpl) Type() int {
@@ -185,6 +281,14 @@ func (i *iteratorImpl) Message() Message {
 	return i.msg
 }
 
+func (i *iteratorImpl) Err() error {
+	return i.err
+}
+
+func (i *iteratorImpl) Skipped() int {
+	return i.skipped
+}
+
 func (i *iteratorImpl) Close() {
 	_, err := i.data.Seek(0, io.SeekEnd)
 	if err != nil {
@@ -195,3 +299,11 @@ func (i *iteratorImpl) Close() {
 func messageHasSize(msgType uint64) bool {
 	return !(msgType == 80 || msgType == 81 || msgType == 82)
 }
+
+// unexpectedEOF reports the end of data in the middle of the message as an error
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
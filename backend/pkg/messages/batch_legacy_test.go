@@ -0,0 +1,335 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+// The iterator before messages pointed into the batch data, kept as the baseline for the benchmarks.
+// Messages were copied out of the batch, decoded into new structs, and every byte was read
+// through a new buffer, which is what legacyReader reproduces.
+
+// legacyReader hides io.ByteReader of the data from the Read* functions
+type legacyReader struct {
+	reader *bytes.Reader
+}
+
+func newLegacyReader(data []byte) *legacyReader {
+	return &legacyReader{reader: bytes.NewReader(data)}
+}
+
+func (r *legacyReader) Read(p []byte) (int, error) {
+	return r.reader.Read(p)
+}
+
+func (r *legacyReader) Seek(offset int64, whence int) (int64, error) {
+	return r.reader.Seek(offset, whence)
+}
+
+func (r *legacyReader) Len() int {
+	return r.reader.Len()
+}
+
+func (r *legacyReader) Size() int64 {
+	return r.reader.Size()
+}
+
+type legacyRawMessage struct {
+	tp      uint64
+	size    uint64
+	data    []byte
+	reader  *legacyReader
+	meta    *message
+	encoded bool
+	skipped *bool
+}
+
+func (m *legacyRawMessage) Encode() []byte {
+	if m.encoded {
+		return m.data
+	}
+	m.data = make([]byte, m.size+1)
+	m.data[0] = uint8(m.tp)
+	m.encoded = true
+	*m.skipped = false
+	_, err := io.ReadFull(m.reader, m.data[1:])
+	if err != nil {
+		log.Printf("message encode err: %s", err)
+		m.data = nil
+		return nil
+	}
+	return m.data
+}
+
+func (m *legacyRawMessage) EncodeWithIndex() []byte {
+	if !m.encoded {
+		m.Encode()
+	}
+	if m.data == nil {
+		return nil
+	}
+	if IsIOSType(int(m.tp)) {
+		return m.data
+	}
+	data := make([]byte, len(m.data)+8)
+	copy(data[8:], m.data[:])
+	binary.LittleEndian.PutUint64(data[0:], m.Meta().Index)
+	return data
+}
+
+func (m *legacyRawMessage) Decode() Message {
+	if !m.encoded {
+		m.Encode()
+	}
+	if m.data == nil {
+		return nil
+	}
+	msg, err := ReadMessage(m.tp, newLegacyReader(m.data[1:]))
+	if err != nil {
+		log.Printf("decode err: %s", err)
+		return nil
+	}
+	msg.Meta().SetMeta(m.meta)
+	return msg
+}
+
+func (m *legacyRawMessage) TypeID() int {
+	return int(m.tp)
+}
+
+func (m *legacyRawMessage) Meta() *message {
+	return m.meta
+}
+
+type legacyIterator struct {
+	data      *legacyReader
+	opts      DecoderOptions
+	index     uint64
+	timestamp int64
+	version   uint64
+	msgType   uint64
+	msgSize   uint64
+	offset    int64
+	canSkip   bool
+	msg       Message
+	url       string
+	err       error
+	skipped   int
+}
+
+func newLegacyIterator(data []byte) Iterator {
+	return &legacyIterator{data: newLegacyReader(data), opts: DefaultDecoderOptions}
+}
+
+func (i *legacyIterator) Next() bool {
+	if i.err != nil {
+		return false
+	}
+	for {
+		ok, broken := i.next()
+		if ok {
+			return true
+		}
+		if !broken {
+			return false
+		}
+		log.Printf("skip broken message, type: %d, offset: %d, index: %d", i.msgType, i.offset, i.index)
+		i.skipped++
+		i.index++
+	}
+}
+
+func (i *legacyIterator) next() (ok bool, broken bool) {
+	if i.canSkip {
+		if _, err := i.data.Seek(int64(i.msgSize), io.SeekCurrent); err != nil {
+			i.fail(err)
+			return false, false
+		}
+	}
+	i.canSkip = false
+
+	if i.data.Len() == 0 {
+		return false, false
+	}
+	i.offset = i.data.Size() - int64(i.data.Len())
+
+	var err error
+	i.msgType, err = ReadUint(i.data)
+	if err != nil {
+		i.fail(fmt.Errorf("can't read message type: %s", err))
+		return false, false
+	}
+
+	sized := i.version > 0 && messageHasSize(i.msgType)
+	if sized {
+		i.msgSize, err = ReadSize(i.data)
+		if err != nil {
+			i.fail(fmt.Errorf("can't read message size: %s", err))
+			return false, false
+		}
+		if i.msgSize > uint64(i.data.Len()) {
+			i.fail(&SizeError{Err: ErrDataTruncated, Size: i.msgSize, Limit: uint64(i.data.Len())})
+			return false, false
+		}
+		i.msg = &legacyRawMessage{
+			tp:      i.msgType,
+			size:    i.msgSize,
+			meta:    &message{},
+			reader:  i.data,
+			skipped: &i.canSkip,
+		}
+		i.canSkip = true
+		if i.opts.MaxMessageSize > 0 && i.msgSize > i.opts.MaxMessageSize {
+			log.Printf("message is too large, type: %d, size: %d", i.msgType, i.msgSize)
+			return false, true
+		}
+	} else {
+		i.msg, err = ReadMessage(i.msgType, i.data)
+		if err != nil {
+			if !strings.HasPrefix(err.Error(), "Unknown message code:") {
+				i.fail(unexpectedEOF(err))
+				return false, false
+			}
+			code := strings.TrimPrefix(err.Error(), "Unknown message code: ")
+			i.msg, err = DecodeExtraMessage(code, i.data)
+			if err != nil {
+				i.fail(unexpectedEOF(err))
+				return false, false
+			}
+		}
+		if size := i.data.Size() - int64(i.data.Len()) - i.offset; i.opts.MaxMessageSize > 0 && uint64(size) > i.opts.MaxMessageSize {
+			i.fail(&SizeError{Err: ErrMessageTooLarge, Size: uint64(size), Limit: i.opts.MaxMessageSize})
+			return false, false
+		}
+		i.msg = transformDeprecated(i.msg)
+	}
+
+	decode := func() Message {
+		msg := i.msg.Decode()
+		if msg == nil && !sized {
+			i.fail(errors.New("can't decode message"))
+		}
+		return msg
+	}
+
+	isBatchMeta := false
+	switch i.msgType {
+	case MsgBatchMetadata:
+		if i.index != 0 {
+			i.fail(errors.New("batch metadata found at the end of the batch"))
+			return false, false
+		}
+		msg := decode()
+		if msg == nil {
+			return false, sized
+		}
+		m := msg.(*BatchMetadata)
+		i.index = m.PageNo<<32 + m.FirstIndex
+		i.timestamp = m.Timestamp
+		i.version = m.Version
+		i.url = m.Location
+		isBatchMeta = true
+		if i.version > 1 {
+			i.fail(fmt.Errorf("unsupported batch version: %d", i.version))
+			return false, false
+		}
+	case MsgBatchMeta:
+		if i.index != 0 {
+			i.fail(errors.New("batch meta found at the end of the batch"))
+			return false, false
+		}
+		msg := decode()
+		if msg == nil {
+			return false, sized
+		}
+		m := msg.(*BatchMeta)
+		i.index = m.PageNo<<32 + m.FirstIndex
+		i.timestamp = m.Timestamp
+		isBatchMeta = true
+	case MsgIOSBatchMeta:
+		if i.index != 0 {
+			i.fail(errors.New("batch meta found at the end of the batch"))
+			return false, false
+		}
+		msg := decode()
+		if msg == nil {
+			return false, sized
+		}
+		m := msg.(*IOSBatchMeta)
+		i.index = m.FirstIndex
+		i.timestamp = int64(m.Timestamp)
+		isBatchMeta = true
+	case MsgTimestamp:
+		msg := decode()
+		if msg == nil {
+			return false, sized
+		}
+		m := msg.(*Timestamp)
+		i.timestamp = int64(m.Timestamp)
+	case MsgSessionStart:
+		msg := decode()
+		if msg == nil {
+			return false, sized
+		}
+		m := msg.(*SessionStart)
+		i.timestamp = int64(m.Timestamp)
+	case MsgSessionEnd:
+		msg := decode()
+		if msg == nil {
+			return false, sized
+		}
+		m := msg.(*SessionEnd)
+		i.timestamp = int64(m.Timestamp)
+	case MsgSetPageLocation:
+		msg := decode()
+		if msg == nil {
+			return false, sized
+		}
+		m := msg.(*SetPageLocation)
+		i.url = m.URL
+	}
+	i.msg.Meta().Index = i.index
+	i.msg.Meta().Timestamp = i.timestamp
+	i.msg.Meta().Url = i.url
+
+	if !isBatchMeta {
+		i.index++
+	}
+	return true, false
+}
+
+func (i *legacyIterator) fail(err error) {
+	i.err = &IteratorError{
+		Type:   i.msgType,
+		Offset: i.offset,
+		Index:  i.index,
+		Err:    err,
+	}
+}
+
+func (i *legacyIterator) Type() int {
+	return int(i.msgType)
+}
+
+func (i *legacyIterator) Message() Message {
+	return i.msg
+}
+
+func (i *legacyIterator) Err() error {
+	return i.err
+}
+
+func (i *legacyIterator) Skipped() int {
+	return i.skipped
+}
+
+func (i *legacyIterator) Close() {
+	if _, err := i.data.Seek(0, io.SeekEnd); err != nil {
+		log.Printf("can't set seek pointer at the end: %s", err)
+	}
+}
@@ -0,0 +1,136 @@
+package messages
+
+import (
+	"strings"
+	"testing"
+)
+
+const benchMessages = 1000
+
+// benchBatch returns the batch of the given version with the typical mix of small and large messages
+func benchBatch(version uint64) []byte {
+	encode := Encode
+	var batch []byte
+	if version == 0 {
+		batch = append(batch, Encode(&BatchMeta{PageNo: 1, FirstIndex: 1, Timestamp: 1600000000000})...)
+	} else {
+		encode = EncodeSized
+		batch = append(batch, Encode(&BatchMetadata{Version: version, PageNo: 1, FirstIndex: 1, Timestamp: 1600000000000, Location: "https://example.com/"})...)
+	}
+	value := strings.Repeat("a", 512)
+	for i := 0; i < benchMessages; i++ {
+		batch = append(batch, encode(&Timestamp{Timestamp: uint64(1600000000000 + i)})...)
+		batch = append(batch, encode(&MouseMove{X: uint64(i), Y: uint64(i)})...)
+		batch = append(batch, encode(&SetNodeAttribute{ID: uint64(i), Name: "class", Value: value})...)
+	}
+	return batch
+}
+
+// benchIterate reads the whole batch, decode is called for every message which isn't filtered by type
+func benchIterate(b *testing.B, newIter func([]byte) Iterator, batch []byte, keep func(tp int) bool, release bool) {
+	b.SetBytes(int64(len(batch)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		iter := newIter(batch)
+		for iter.Next() {
+			if !keep(iter.Type()) {
+				continue
+			}
+			msg := iter.Message().Decode()
+			if msg == nil {
+				b.Fatalf("can't decode message of type %d", iter.Type())
+			}
+			if release {
+				ReleaseMessage(msg)
+			}
+		}
+		if err := iter.Err(); err != nil {
+			b.Fatal(err)
+		}
+		iter.Close()
+	}
+}
+
+func keepAll(int) bool { return true }
+
+func keepTimestamp(tp int) bool { return tp == MsgTimestamp }
+
+// Version 0 batches are decoded completely by the iterator
+func BenchmarkIteratorDecodeV0(b *testing.B) {
+	benchIterate(b, NewIterator, benchBatch(0), keepAll, false)
+}
+
+func BenchmarkIteratorFilterV0(b *testing.B) {
+	benchIterate(b, NewIterator, benchBatch(0), keepTimestamp, false)
+}
+
+// Version 1 batches are decoded only on demand
+func BenchmarkIteratorDecodeV1(b *testing.B) {
+	benchIterate(b, NewIterator, benchBatch(1), keepAll, false)
+}
+
+func BenchmarkIteratorDecodeReleaseV1(b *testing.B) {
+	benchIterate(b, NewIterator, benchBatch(1), keepAll, true)
+}
+
+func BenchmarkIteratorFilterV1(b *testing.B) {
+	benchIterate(b, NewIterator, benchBatch(1), keepTimestamp, false)
+}
+
+func BenchmarkIteratorTypesV1(b *testing.B) {
+	benchIterate(b, NewIterator, benchBatch(1), func(int) bool { return false }, false)
+}
+
+// benchWrite reads the whole batch and writes every message in the session file format like sink does
+func benchWrite(b *testing.B, newIter func([]byte) Iterator, write func(buf []byte, msg Message) []byte) {
+	batch := benchBatch(1)
+	b.SetBytes(int64(len(batch)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	var buf []byte
+	for n := 0; n < b.N; n++ {
+		iter := newIter(batch)
+		for iter.Next() {
+			buf = write(buf, iter.Message())
+		}
+		if err := iter.Err(); err != nil {
+			b.Fatal(err)
+		}
+		iter.Close()
+	}
+}
+
+func BenchmarkIteratorWriteV1(b *testing.B) {
+	benchWrite(b, NewIterator, func(buf []byte, msg Message) []byte {
+		return AppendWithIndex(buf[:0], msg)
+	})
+}
+
+// Baseline of the iterator which copied every message, see batch_legacy_test.go
+
+func BenchmarkLegacyIteratorDecodeV0(b *testing.B) {
+	benchIterate(b, newLegacyIterator, benchBatch(0), keepAll, false)
+}
+
+func BenchmarkLegacyIteratorFilterV0(b *testing.B) {
+	benchIterate(b, newLegacyIterator, benchBatch(0), keepTimestamp, false)
+}
+
+func BenchmarkLegacyIteratorDecodeV1(b *testing.B) {
+	benchIterate(b, newLegacyIterator, benchBatch(1), keepAll, false)
+}
+
+func BenchmarkLegacyIteratorFilterV1(b *testing.B) {
+	benchIterate(b, newLegacyIterator, benchBatch(1), keepTimestamp, false)
+}
+
+func BenchmarkLegacyIteratorTypesV1(b *testing.B) {
+	benchIterate(b, newLegacyIterator, benchBatch(1), func(int) bool { return false }, false)
+}
+
+func BenchmarkLegacyIteratorWriteV1(b *testing.B) {
+	benchWrite(b, newLegacyIterator, func(buf []byte, msg Message) []byte {
+		return msg.EncodeWithIndex()
+	})
+}
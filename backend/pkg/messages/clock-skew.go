@@ -0,0 +1,26 @@
+package messages
+
+// ServerPageNo is the page number of batches composed by the server, so indexes of their messages
+// don't intersect with tracker ones. Timestamps of such messages come from the server's clock.
+const ServerPageNo = 0xFFFF
+
+// IsServerIndex returns true if the message index belongs to a batch composed by the server
+func IsServerIndex(index uint64) bool {
+	return index>>32 == ServerPageNo
+}
+
+// ToUserTime converts the server's timestamp to the clock of the user with the given skew
+func ToUserTime(timestamp uint64, clockSkew int64) uint64 {
+	if timestamp == 0 {
+		return 0
+	}
+	return uint64(int64(timestamp) - clockSkew)
+}
+
+// ToServerTime converts the user's timestamp to the server's clock
+func ToServerTime(timestamp uint64, clockSkew int64) uint64 {
+	if timestamp == 0 {
+		return 0
+	}
+	return uint64(int64(timestamp) + clockSkew)
+}
@@ -0,0 +1,77 @@
+package messages
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Decoding errors, SizeError wraps them with the actual values
+var (
+	ErrFieldTooLarge   = errors.New("field is too large")
+	ErrMessageTooLarge = errors.New("message is too large")
+	ErrDataTruncated   = errors.New("length exceeds the rest of data")
+)
+
+// SizeError is returned when the length read from data is over the limit
+type SizeError struct {
+	Err   error
+	Size  uint64
+	Limit uint64
+}
+
+func (e *SizeError) Error() string {
+	return fmt.Sprintf("%s: %d > %d", e.Err, e.Size, e.Limit)
+}
+
+func (e *SizeError) Unwrap() error {
+	return e.Err
+}
+
+// DecoderOptions limits the memory which can be allocated while decoding messages
+type DecoderOptions struct {
+	MaxFieldSize   uint64 // Maximum length of string and data fields
+	MaxMessageSize uint64 // Maximum size of the whole message
+}
+
+var DefaultDecoderOptions = DecoderOptions{
+	MaxFieldSize:   10e6,
+	MaxMessageSize: 10e6,
+}
+
+// NewDecoderOptions returns limits for batches which can't be larger than the queue message
+func NewDecoderOptions(messageSizeLimit int) DecoderOptions {
+	if messageSizeLimit <= 0 {
+		return DefaultDecoderOptions
+	}
+	return DecoderOptions{
+		MaxFieldSize:   uint64(messageSizeLimit),
+		MaxMessageSize: uint64(messageSizeLimit),
+	}
+}
+
+// decoderReader passes decoder options to the Read* functions along with the data
+type decoderReader struct {
+	*bytes.Reader
+	opts *DecoderOptions
+}
+
+func newDecoderReader(data []byte, opts *DecoderOptions) *decoderReader {
+	return &decoderReader{Reader: bytes.NewReader(data), opts: opts}
+}
+
+// checkLength validates the length of a field before allocating memory for it
+func checkLength(reader io.Reader, length uint64) error {
+	limit := DefaultDecoderOptions.MaxFieldSize
+	if r, ok := reader.(*decoderReader); ok {
+		limit = r.opts.MaxFieldSize
+	}
+	if limit > 0 && length > limit {
+		return &SizeError{Err: ErrFieldTooLarge, Size: length, Limit: limit}
+	}
+	if r, ok := reader.(interface{ Len() int }); ok && length > uint64(r.Len()) {
+		return &SizeError{Err: ErrDataTruncated, Size: length, Limit: uint64(r.Len())}
+	}
+	return nil
+}
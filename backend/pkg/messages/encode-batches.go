@@ -0,0 +1,41 @@
+package messages
+
+// EncodeBatches splits messages into batches of version 1 with size up to sizeLimit (a single bigger message makes its own batch).
+// Every batch starts with BatchMetadata keeping the current timestamp, page location and index of the first message.
+func EncodeBatches(msgs []Message, pageNo uint64, sizeLimit int) [][]byte {
+	var (
+		batches   [][]byte
+		batch     []byte
+		index     uint64
+		timestamp int64
+		location  string
+	)
+	for _, msg := range msgs {
+		encoded := EncodeSized(msg)
+		if batch != nil && len(batch)+len(encoded) > sizeLimit {
+			batches = append(batches, batch)
+			batch = nil
+		}
+		if batch == nil {
+			batch = Encode(&BatchMetadata{
+				Version:    1,
+				PageNo:     pageNo,
+				FirstIndex: index,
+				Timestamp:  timestamp,
+				Location:   location,
+			})
+		}
+		batch = append(batch, encoded...)
+		index++
+		switch m := msg.(type) {
+		case *Timestamp:
+			timestamp = int64(m.Timestamp)
+		case *SetPageLocation:
+			location = m.URL
+		}
+	}
+	if batch != nil {
+		batches = append(batches, batch)
+	}
+	return batches
+}
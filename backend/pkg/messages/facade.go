@@ -3,3 +3,21 @@ package messages
 func Encode(msg Message) []byte {
 	return msg.Encode()
 }
+
+// EncodeSized encodes message in the format of batch version 1, where most of messages have size after the type
+func EncodeSized(msg Message) []byte {
+	encoded := msg.Encode()
+	if !messageHasSize(uint64(msg.TypeID())) {
+		return encoded
+	}
+	body, err := messageBody(encoded)
+	if err != nil {
+		return encoded
+	}
+	typeSize := len(encoded) - len(body)
+	sized := make([]byte, len(encoded)+sizeBytes)
+	copy(sized, encoded[:typeSize])
+	WriteSize(uint64(len(body)), sized, typeSize)
+	copy(sized[typeSize+sizeBytes:], body)
+	return sized
+}
@@ -10,6 +10,9 @@ func GetTimestamp(message Message) uint64 {
 	case *IOSSessionStart:
 		return msg.Timestamp
 
+	case *AndroidSessionStart:
+		return msg.Timestamp
+
 	case *IOSSessionEnd:
 		return msg.Timestamp
 
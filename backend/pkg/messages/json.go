@@ -0,0 +1,139 @@
+package messages
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONMessage is the JSON representation of the message with its meta information
+type JSONMessage struct {
+	Type      string          `json:"type"`
+	TypeID    int             `json:"typeID"`
+	Index     uint64          `json:"index"`
+	Timestamp int64           `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+var messageTypeIDs = func() map[string]int {
+	ids := make(map[string]int, len(messageNames))
+	for id, name := range messageNames {
+		ids[name] = id
+	}
+	return ids
+}()
+
+// TypeName returns the name of the message type or an empty string for unknown types
+func TypeName(id int) string {
+	return messageNames[id]
+}
+
+// TypeIDByName returns the ID of the message type with the given name
+func TypeIDByName(name string) (int, bool) {
+	id, ok := messageTypeIDs[name]
+	return id, ok
+}
+
+// EncodeJSON returns the JSON representation of the message
+func EncodeJSON(msg Message) ([]byte, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal message: %s", err)
+	}
+	return json.Marshal(&JSONMessage{
+		Type:      TypeName(msg.TypeID()),
+		TypeID:    msg.TypeID(),
+		Index:     msg.Meta().Index,
+		Timestamp: msg.Meta().Timestamp,
+		Data:      data,
+	})
+}
+
+// DecodeJSON parses the message, its type is taken from the type name or from typeID if the name is empty
+func DecodeJSON(data []byte) (Message, error) {
+	jsonMsg := &JSONMessage{}
+	if err := json.Unmarshal(data, jsonMsg); err != nil {
+		return nil, fmt.Errorf("can't unmarshal message: %s", err)
+	}
+	typeID := jsonMsg.TypeID
+	if jsonMsg.Type != "" {
+		id, ok := TypeIDByName(jsonMsg.Type)
+		if !ok {
+			return nil, fmt.Errorf("unknown message type: %s", jsonMsg.Type)
+		}
+		typeID = id
+	}
+	msg := newMessage(typeID)
+	if msg == nil {
+		return nil, fmt.Errorf("unknown message type: %d", typeID)
+	}
+	if len(jsonMsg.Data) > 0 {
+		if err := json.Unmarshal(jsonMsg.Data, msg); err != nil {
+			return nil, fmt.Errorf("can't unmarshal %s message: %s", TypeName(typeID), err)
+		}
+	}
+	msg.Meta().Index = jsonMsg.Index
+	msg.Meta().Timestamp = jsonMsg.Timestamp
+	return msg, nil
+}
+
+// BatchToJSON converts the binary batch to JSON lines, one message per line.
+// Batches with broken messages are rejected instead of being converted partially.
+func BatchToJSON(batch []byte) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, len(batch)*4))
+	iter := NewIterator(batch)
+	for iter.Next() {
+		msg := iter.Message().Decode()
+		if msg == nil {
+			return nil, fmt.Errorf("can't decode message, type: %d", iter.Type())
+		}
+		line, err := EncodeJSON(msg)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	// JSON lines have to convert back to the same batch
+	if skipped := iter.Skipped(); skipped > 0 {
+		return nil, fmt.Errorf("%d broken messages are skipped", skipped)
+	}
+	return buf.Bytes(), nil
+}
+
+// BatchFromJSON converts JSON lines to the binary batch. Messages are written as they are,
+// so the batch has version 1 format only if it starts with BatchMetadata of that version.
+func BatchFromJSON(data []byte) ([]byte, error) {
+	batch := make([]byte, 0, len(data)/4)
+	sized := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		msg, err := DecodeJSON(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNo, err)
+		}
+		if len(batch) == 0 {
+			if meta, ok := msg.(*BatchMetadata); ok && meta.Version > 0 {
+				sized = true
+			}
+		}
+		if sized {
+			batch = append(batch, EncodeSized(msg)...)
+		} else {
+			batch = append(batch, Encode(msg)...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("can't read JSON lines: %s", err)
+	}
+	return batch, nil
+}
@@ -0,0 +1,70 @@
+package messages
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// jsonTestBatch returns the batch of the given version with messages of different field types
+func jsonTestBatch(version uint64) []byte {
+	encode := Encode
+	var batch []byte
+	if version == 0 {
+		batch = Encode(&BatchMeta{PageNo: 1, FirstIndex: 1, Timestamp: 1600000000000})
+	} else {
+		encode = EncodeSized
+		batch = Encode(&BatchMetadata{Version: version, PageNo: 1, FirstIndex: 1, Timestamp: 1600000000000, Location: "https://example.com/"})
+	}
+	for _, msg := range []Message{
+		&Timestamp{Timestamp: 1600000000001},
+		&SetPageLocation{URL: "https://example.com/page", Referrer: "https://example.com/", NavigationStart: 1600000000000},
+		&CreateElementNode{ID: 2, ParentID: 1, Index: 0, Tag: "DIV", SVG: false},
+		&SetNodeAttribute{ID: 2, Name: "class", Value: "header \"main\"\n"},
+		&SetPageVisibility{Hidden: true},
+		&MouseMove{X: 100, Y: 200},
+	} {
+		batch = append(batch, encode(msg)...)
+	}
+	return batch
+}
+
+func TestBatchJSONRoundTrip(t *testing.T) {
+	for _, version := range []uint64{0, 1} {
+		batch := jsonTestBatch(version)
+		lines, err := BatchToJSON(batch)
+		if err != nil {
+			t.Fatalf("version %d: %s", version, err)
+		}
+		if n := strings.Count(string(lines), "\n"); n != 7 {
+			t.Errorf("version %d: got %d lines, want 7", version, n)
+		}
+		restored, err := BatchFromJSON(lines)
+		if err != nil {
+			t.Fatalf("version %d: %s", version, err)
+		}
+		if !bytes.Equal(restored, batch) {
+			t.Errorf("version %d: restored batch differs\ngot  %v\nwant %v", version, restored, batch)
+		}
+	}
+}
+
+func TestBatchToJSONErrors(t *testing.T) {
+	valid := jsonTestBatch(1)
+	tests := []struct {
+		name  string
+		batch []byte
+	}{
+		// Framing is fine, but the timestamp is missing, so the message is skipped by the iterator
+		{"skipped message", append(append([]byte{}, valid...), sizedMessage(MsgTimestamp, nil)...)},
+		{"broken message", append(append([]byte{}, valid...), sizedMessage(MsgSetNodeAttribute, []byte{1})...)},
+		{"truncated", valid[:len(valid)-1]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if lines, err := BatchToJSON(tt.batch); err == nil {
+				t.Errorf("batch is converted partially:\n%s", lines)
+			}
+		})
+	}
+}
@@ -1,9 +1,9 @@
 package messages
 
 type message struct {
-	Timestamp int64
-	Index     uint64
-	Url       string
+	Timestamp int64  `json:"-"`
+	Index     uint64 `json:"-"`
+	Url       string `json:"-"`
 }
 
 func (m *message) Meta() *message {
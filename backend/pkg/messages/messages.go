@@ -14,6 +14,10 @@ const (
 
 	MsgSessionStart = 1
 
+	MsgSessionGeo = 83
+
+	MsgSessionClockSkew = 84
+
 	MsgSessionEnd = 3
 
 	MsgSetPageLocation = 4
@@ -122,6 +126,8 @@ const (
 
 	MsgPerformanceTrackAggr = 56
 
+	MsgSessionAssociation = 57
+
 	MsgLongTask = 59
 
 	MsgSetNodeAttributeURLBased = 60
@@ -162,6 +168,8 @@ const (
 
 	MsgIOSSessionStart = 90
 
+	MsgAndroidSessionStart = 112
+
 	MsgIOSSessionEnd = 91
 
 	MsgIOSMetadata = 92
@@ -199,9 +207,9 @@ const (
 
 type BatchMeta struct {
 	message
-	PageNo     uint64
-	FirstIndex uint64
-	Timestamp  int64
+	PageNo     uint64 `json:"pageNo"`
+	FirstIndex uint64 `json:"firstIndex"`
+	Timestamp  int64  `json:"timestamp"`
 }
 
 func (msg *BatchMeta) Encode() []byte {
@@ -235,11 +243,11 @@ func (msg *BatchMeta) TypeID() int {
 
 type BatchMetadata struct {
 	message
-	Version    uint64
-	PageNo     uint64
-	FirstIndex uint64
-	Timestamp  int64
-	Location   string
+	Version    uint64 `json:"version"`
+	PageNo     uint64 `json:"pageNo"`
+	FirstIndex uint64 `json:"firstIndex"`
+	Timestamp  int64  `json:"timestamp"`
+	Location   string `json:"location"`
 }
 
 func (msg *BatchMetadata) Encode() []byte {
@@ -275,8 +283,8 @@ func (msg *BatchMetadata) TypeID() int {
 
 type PartitionedMessage struct {
 	message
-	PartNo    uint64
-	PartTotal uint64
+	PartNo    uint64 `json:"partNo"`
+	PartTotal uint64 `json:"partTotal"`
 }
 
 func (msg *PartitionedMessage) Encode() []byte {
@@ -309,7 +317,7 @@ func (msg *PartitionedMessage) TypeID() int {
 
 type Timestamp struct {
 	message
-	Timestamp uint64
+	Timestamp uint64 `json:"timestamp"`
 }
 
 func (msg *Timestamp) Encode() []byte {
@@ -341,22 +349,22 @@ func (msg *Timestamp) TypeID() int {
 
 type SessionStart struct {
 	message
-	Timestamp            uint64
-	ProjectID            uint64
-	TrackerVersion       string
-	RevID                string
-	UserUUID             string
-	UserAgent            string
-	UserOS               string
-	UserOSVersion        string
-	UserBrowser          string
-	UserBrowserVersion   string
-	UserDevice           string
-	UserDeviceType       string
-	UserDeviceMemorySize uint64
-	UserDeviceHeapSize   uint64
-	UserCountry          string
-	UserID               string
+	Timestamp            uint64 `json:"timestamp"`
+	ProjectID            uint64 `json:"projectID"`
+	TrackerVersion       string `json:"trackerVersion"`
+	RevID                string `json:"revID"`
+	UserUUID             string `json:"userUUID"`
+	UserAgent            string `json:"userAgent"`
+	UserOS               string `json:"userOS"`
+	UserOSVersion        string `json:"userOSVersion"`
+	UserBrowser          string `json:"userBrowser"`
+	UserBrowserVersion   string `json:"userBrowserVersion"`
+	UserDevice           string `json:"userDevice"`
+	UserDeviceType       string `json:"userDeviceType"`
+	UserDeviceMemorySize uint64 `json:"userDeviceMemorySize"`
+	UserDeviceHeapSize   uint64 `json:"userDeviceHeapSize"`
+	UserCountry          string `json:"userCountry"`
+	UserID               string `json:"userID"`
 }
 
 func (msg *SessionStart) Encode() []byte {
@@ -401,9 +409,85 @@ func (msg *SessionStart) TypeID() int {
 	return 1
 }
 
+type SessionGeo struct {
+	message
+	UserRegion    string `json:"userRegion"`
+	UserCity      string `json:"userCity"`
+	UserLatitude  int64  `json:"userLatitude"`
+	UserLongitude int64  `json:"userLongitude"`
+	UserTimezone  string `json:"userTimezone"`
+	UserASN       uint64 `json:"userASN"`
+	UserASNOrg    string `json:"userASNOrg"`
+}
+
+func (msg *SessionGeo) Encode() []byte {
+	buf := make([]byte, 71+len(msg.UserRegion)+len(msg.UserCity)+len(msg.UserTimezone)+len(msg.UserASNOrg))
+	buf[0] = 83
+	p := 1
+	p = WriteString(msg.UserRegion, buf, p)
+	p = WriteString(msg.UserCity, buf, p)
+	p = WriteInt(msg.UserLatitude, buf, p)
+	p = WriteInt(msg.UserLongitude, buf, p)
+	p = WriteString(msg.UserTimezone, buf, p)
+	p = WriteUint(msg.UserASN, buf, p)
+	p = WriteString(msg.UserASNOrg, buf, p)
+	return buf[:p]
+}
+
+func (msg *SessionGeo) EncodeWithIndex() []byte {
+	encoded := msg.Encode()
+	if IsIOSType(msg.TypeID()) {
+		return encoded
+	}
+	data := make([]byte, len(encoded)+8)
+	copy(data[8:], encoded[:])
+	binary.LittleEndian.PutUint64(data[0:], msg.Meta().Index)
+	return data
+}
+
+func (msg *SessionGeo) Decode() Message {
+	return msg
+}
+
+func (msg *SessionGeo) TypeID() int {
+	return 83
+}
+
+type SessionClockSkew struct {
+	message
+	ClockSkew int64 `json:"clockSkew"`
+}
+
+func (msg *SessionClockSkew) Encode() []byte {
+	buf := make([]byte, 11)
+	buf[0] = 84
+	p := 1
+	p = WriteInt(msg.ClockSkew, buf, p)
+	return buf[:p]
+}
+
+func (msg *SessionClockSkew) EncodeWithIndex() []byte {
+	encoded := msg.Encode()
+	if IsIOSType(msg.TypeID()) {
+		return encoded
+	}
+	data := make([]byte, len(encoded)+8)
+	copy(data[8:], encoded[:])
+	binary.LittleEndian.PutUint64(data[0:], msg.Meta().Index)
+	return data
+}
+
+func (msg *SessionClockSkew) Decode() Message {
+	return msg
+}
+
+func (msg *SessionClockSkew) TypeID() int {
+	return 84
+}
+
 type SessionEnd struct {
 	message
-	Timestamp uint64
+	Timestamp uint64 `json:"timestamp"`
 }
 
 func (msg *SessionEnd) Encode() []byte {
@@ -435,9 +519,9 @@ func (msg *SessionEnd) TypeID() int {
 
 type SetPageLocation struct {
 	message
-	URL             string
-	Referrer        string
-	NavigationStart uint64
+	URL             string `json:"url"`
+	Referrer        string `json:"referrer"`
+	NavigationStart uint64 `json:"navigationStart"`
 }
 
 func (msg *SetPageLocation) Encode() []byte {
@@ -471,8 +555,8 @@ func (msg *SetPageLocation) TypeID() int {
 
 type SetViewportSize struct {
 	message
-	Width  uint64
-	Height uint64
+	Width  uint64 `json:"width"`
+	Height uint64 `json:"height"`
 }
 
 func (msg *SetViewportSize) Encode() []byte {
@@ -505,8 +589,8 @@ func (msg *SetViewportSize) TypeID() int {
 
 type SetViewportScroll struct {
 	message
-	X int64
-	Y int64
+	X int64 `json:"x"`
+	Y int64 `json:"y"`
 }
 
 func (msg *SetViewportScroll) Encode() []byte {
@@ -570,11 +654,11 @@ func (msg *CreateDocument) TypeID() int {
 
 type CreateElementNode struct {
 	message
-	ID       uint64
-	ParentID uint64
-	index    uint64
-	Tag      string
-	SVG      bool
+	ID       uint64 `json:"id"`
+	ParentID uint64 `json:"parentID"`
+	Index    uint64 `json:"index"`
+	Tag      string `json:"tag"`
+	SVG      bool   `json:"svg"`
 }
 
 func (msg *CreateElementNode) Encode() []byte {
@@ -583,7 +667,7 @@ func (msg *CreateElementNode) Encode() []byte {
 	p := 1
 	p = WriteUint(msg.ID, buf, p)
 	p = WriteUint(msg.ParentID, buf, p)
-	p = WriteUint(msg.index, buf, p)
+	p = WriteUint(msg.Index, buf, p)
 	p = WriteString(msg.Tag, buf, p)
 	p = WriteBoolean(msg.SVG, buf, p)
 	return buf[:p]
@@ -610,9 +694,9 @@ func (msg *CreateElementNode) TypeID() int {
 
 type CreateTextNode struct {
 	message
-	ID       uint64
-	ParentID uint64
-	Index    uint64
+	ID       uint64 `json:"id"`
+	ParentID uint64 `json:"parentID"`
+	Index    uint64 `json:"index"`
 }
 
 func (msg *CreateTextNode) Encode() []byte {
@@ -646,9 +730,9 @@ func (msg *CreateTextNode) TypeID() int {
 
 type MoveNode struct {
 	message
-	ID       uint64
-	ParentID uint64
-	Index    uint64
+	ID       uint64 `json:"id"`
+	ParentID uint64 `json:"parentID"`
+	Index    uint64 `json:"index"`
 }
 
 func (msg *MoveNode) Encode() []byte {
@@ -682,7 +766,7 @@ func (msg *MoveNode) TypeID() int {
 
 type RemoveNode struct {
 	message
-	ID uint64
+	ID uint64 `json:"id"`
 }
 
 func (msg *RemoveNode) Encode() []byte {
@@ -714,9 +798,9 @@ func (msg *RemoveNode) TypeID() int {
 
 type SetNodeAttribute struct {
 	message
-	ID    uint64
-	Name  string
-	Value string
+	ID    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 func (msg *SetNodeAttribute) Encode() []byte {
@@ -750,8 +834,8 @@ func (msg *SetNodeAttribute) TypeID() int {
 
 type RemoveNodeAttribute struct {
 	message
-	ID   uint64
-	Name string
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
 }
 
 func (msg *RemoveNodeAttribute) Encode() []byte {
@@ -784,8 +868,8 @@ func (msg *RemoveNodeAttribute) TypeID() int {
 
 type SetNodeData struct {
 	message
-	ID   uint64
-	Data string
+	ID   uint64 `json:"id"`
+	Data string `json:"data"`
 }
 
 func (msg *SetNodeData) Encode() []byte {
@@ -818,8 +902,8 @@ func (msg *SetNodeData) TypeID() int {
 
 type SetCSSData struct {
 	message
-	ID   uint64
-	Data string
+	ID   uint64 `json:"id"`
+	Data string `json:"data"`
 }
 
 func (msg *SetCSSData) Encode() []byte {
@@ -852,9 +936,9 @@ func (msg *SetCSSData) TypeID() int {
 
 type SetNodeScroll struct {
 	message
-	ID uint64
-	X  int64
-	Y  int64
+	ID uint64 `json:"id"`
+	X  int64  `json:"x"`
+	Y  int64  `json:"y"`
 }
 
 func (msg *SetNodeScroll) Encode() []byte {
@@ -888,8 +972,8 @@ func (msg *SetNodeScroll) TypeID() int {
 
 type SetInputTarget struct {
 	message
-	ID    uint64
-	Label string
+	ID    uint64 `json:"id"`
+	Label string `json:"label"`
 }
 
 func (msg *SetInputTarget) Encode() []byte {
@@ -922,9 +1006,9 @@ func (msg *SetInputTarget) TypeID() int {
 
 type SetInputValue struct {
 	message
-	ID    uint64
-	Value string
-	Mask  int64
+	ID    uint64 `json:"id"`
+	Value string `json:"value"`
+	Mask  int64  `json:"mask"`
 }
 
 func (msg *SetInputValue) Encode() []byte {
@@ -958,8 +1042,8 @@ func (msg *SetInputValue) TypeID() int {
 
 type SetInputChecked struct {
 	message
-	ID      uint64
-	Checked bool
+	ID      uint64 `json:"id"`
+	Checked bool   `json:"checked"`
 }
 
 func (msg *SetInputChecked) Encode() []byte {
@@ -992,8 +1076,8 @@ func (msg *SetInputChecked) TypeID() int {
 
 type MouseMove struct {
 	message
-	X uint64
-	Y uint64
+	X uint64 `json:"x"`
+	Y uint64 `json:"y"`
 }
 
 func (msg *MouseMove) Encode() []byte {
@@ -1026,9 +1110,9 @@ func (msg *MouseMove) TypeID() int {
 
 type MouseClickDepricated struct {
 	message
-	ID             uint64
-	HesitationTime uint64
-	Label          string
+	ID             uint64 `json:"id"`
+	HesitationTime uint64 `json:"hesitationTime"`
+	Label          string `json:"label"`
 }
 
 func (msg *MouseClickDepricated) Encode() []byte {
@@ -1062,8 +1146,8 @@ func (msg *MouseClickDepricated) TypeID() int {
 
 type ConsoleLog struct {
 	message
-	Level string
-	Value string
+	Level string `json:"level"`
+	Value string `json:"value"`
 }
 
 func (msg *ConsoleLog) Encode() []byte {
@@ -1096,15 +1180,15 @@ func (msg *ConsoleLog) TypeID() int {
 
 type PageLoadTiming struct {
 	message
-	RequestStart               uint64
-	ResponseStart              uint64
-	ResponseEnd                uint64
-	DomContentLoadedEventStart uint64
-	DomContentLoadedEventEnd   uint64
-	LoadEventStart             uint64
-	LoadEventEnd               uint64
-	FirstPaint                 uint64
-	FirstContentfulPaint       uint64
+	RequestStart               uint64 `json:"requestStart"`
+	ResponseStart              uint64 `json:"responseStart"`
+	ResponseEnd                uint64 `json:"responseEnd"`
+	DomContentLoadedEventStart uint64 `json:"domContentLoadedEventStart"`
+	DomContentLoadedEventEnd   uint64 `json:"domContentLoadedEventEnd"`
+	LoadEventStart             uint64 `json:"loadEventStart"`
+	LoadEventEnd               uint64 `json:"loadEventEnd"`
+	FirstPaint                 uint64 `json:"firstPaint"`
+	FirstContentfulPaint       uint64 `json:"firstContentfulPaint"`
 }
 
 func (msg *PageLoadTiming) Encode() []byte {
@@ -1144,9 +1228,9 @@ func (msg *PageLoadTiming) TypeID() int {
 
 type PageRenderTiming struct {
 	message
-	SpeedIndex        uint64
-	VisuallyComplete  uint64
-	TimeToInteractive uint64
+	SpeedIndex        uint64 `json:"speedIndex"`
+	VisuallyComplete  uint64 `json:"visuallyComplete"`
+	TimeToInteractive uint64 `json:"timeToInteractive"`
 }
 
 func (msg *PageRenderTiming) Encode() []byte {
@@ -1180,9 +1264,9 @@ func (msg *PageRenderTiming) TypeID() int {
 
 type JSException struct {
 	message
-	Name    string
-	Message string
-	Payload string
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	Payload string `json:"payload"`
 }
 
 func (msg *JSException) Encode() []byte {
@@ -1216,11 +1300,11 @@ func (msg *JSException) TypeID() int {
 
 type IntegrationEvent struct {
 	message
-	Timestamp uint64
-	Source    string
-	Name      string
-	Message   string
-	Payload   string
+	Timestamp uint64 `json:"timestamp"`
+	Source    string `json:"source"`
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+	Payload   string `json:"payload"`
 }
 
 func (msg *IntegrationEvent) Encode() []byte {
@@ -1256,8 +1340,8 @@ func (msg *IntegrationEvent) TypeID() int {
 
 type RawCustomEvent struct {
 	message
-	Name    string
-	Payload string
+	Name    string `json:"name"`
+	Payload string `json:"payload"`
 }
 
 func (msg *RawCustomEvent) Encode() []byte {
@@ -1290,7 +1374,7 @@ func (msg *RawCustomEvent) TypeID() int {
 
 type UserID struct {
 	message
-	ID string
+	ID string `json:"id"`
 }
 
 func (msg *UserID) Encode() []byte {
@@ -1322,7 +1406,7 @@ func (msg *UserID) TypeID() int {
 
 type UserAnonymousID struct {
 	message
-	ID string
+	ID string `json:"id"`
 }
 
 func (msg *UserAnonymousID) Encode() []byte {
@@ -1354,8 +1438,8 @@ func (msg *UserAnonymousID) TypeID() int {
 
 type Metadata struct {
 	message
-	Key   string
-	Value string
+	Key   string `json:"key"`
+	Value string `json:"value"`
 }
 
 func (msg *Metadata) Encode() []byte {
@@ -1388,23 +1472,23 @@ func (msg *Metadata) TypeID() int {
 
 type PageEvent struct {
 	message
-	MessageID                  uint64
-	Timestamp                  uint64
-	URL                        string
-	Referrer                   string
-	Loaded                     bool
-	RequestStart               uint64
-	ResponseStart              uint64
-	ResponseEnd                uint64
-	DomContentLoadedEventStart uint64
-	DomContentLoadedEventEnd   uint64
-	LoadEventStart             uint64
-	LoadEventEnd               uint64
-	FirstPaint                 uint64
-	FirstContentfulPaint       uint64
-	SpeedIndex                 uint64
-	VisuallyComplete           uint64
-	TimeToInteractive          uint64
+	MessageID                  uint64 `json:"messageID"`
+	Timestamp                  uint64 `json:"timestamp"`
+	URL                        string `json:"url"`
+	Referrer                   string `json:"referrer"`
+	Loaded                     bool   `json:"loaded"`
+	RequestStart               uint64 `json:"requestStart"`
+	ResponseStart              uint64 `json:"responseStart"`
+	ResponseEnd                uint64 `json:"responseEnd"`
+	DomContentLoadedEventStart uint64 `json:"domContentLoadedEventStart"`
+	DomContentLoadedEventEnd   uint64 `json:"domContentLoadedEventEnd"`
+	LoadEventStart             uint64 `json:"loadEventStart"`
+	LoadEventEnd               uint64 `json:"loadEventEnd"`
+	FirstPaint                 uint64 `json:"firstPaint"`
+	FirstContentfulPaint       uint64 `json:"firstContentfulPaint"`
+	SpeedIndex                 uint64 `json:"speedIndex"`
+	VisuallyComplete           uint64 `json:"visuallyComplete"`
+	TimeToInteractive          uint64 `json:"timeToInteractive"`
 }
 
 func (msg *PageEvent) Encode() []byte {
@@ -1452,11 +1536,11 @@ func (msg *PageEvent) TypeID() int {
 
 type InputEvent struct {
 	message
-	MessageID   uint64
-	Timestamp   uint64
-	Value       string
-	ValueMasked bool
-	Label       string
+	MessageID   uint64 `json:"messageID"`
+	Timestamp   uint64 `json:"timestamp"`
+	Value       string `json:"value"`
+	ValueMasked bool   `json:"valueMasked"`
+	Label       string `json:"label"`
 }
 
 func (msg *InputEvent) Encode() []byte {
@@ -1492,11 +1576,11 @@ func (msg *InputEvent) TypeID() int {
 
 type ClickEvent struct {
 	message
-	MessageID      uint64
-	Timestamp      uint64
-	HesitationTime uint64
-	Label          string
-	Selector       string
+	MessageID      uint64 `json:"messageID"`
+	Timestamp      uint64 `json:"timestamp"`
+	HesitationTime uint64 `json:"hesitationTime"`
+	Label          string `json:"label"`
+	Selector       string `json:"selector"`
 }
 
 func (msg *ClickEvent) Encode() []byte {
@@ -1532,12 +1616,12 @@ func (msg *ClickEvent) TypeID() int {
 
 type ErrorEvent struct {
 	message
-	MessageID uint64
-	Timestamp uint64
-	Source    string
-	Name      string
-	Message   string
-	Payload   string
+	MessageID uint64 `json:"messageID"`
+	Timestamp uint64 `json:"timestamp"`
+	Source    string `json:"source"`
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+	Payload   string `json:"payload"`
 }
 
 func (msg *ErrorEvent) Encode() []byte {
@@ -1574,18 +1658,18 @@ func (msg *ErrorEvent) TypeID() int {
 
 type ResourceEvent struct {
 	message
-	MessageID       uint64
-	Timestamp       uint64
-	Duration        uint64
-	TTFB            uint64
-	HeaderSize      uint64
-	EncodedBodySize uint64
-	DecodedBodySize uint64
-	URL             string
-	Type            string
-	Success         bool
-	Method          string
-	Status          uint64
+	MessageID       uint64 `json:"messageID"`
+	Timestamp       uint64 `json:"timestamp"`
+	Duration        uint64 `json:"duration"`
+	TTFB            uint64 `json:"ttfb"`
+	HeaderSize      uint64 `json:"headerSize"`
+	EncodedBodySize uint64 `json:"encodedBodySize"`
+	DecodedBodySize uint64 `json:"decodedBodySize"`
+	URL             string `json:"url"`
+	Type            string `json:"type"`
+	Success         bool   `json:"success"`
+	Method          string `json:"method"`
+	Status          uint64 `json:"status"`
 }
 
 func (msg *ResourceEvent) Encode() []byte {
@@ -1628,10 +1712,10 @@ func (msg *ResourceEvent) TypeID() int {
 
 type CustomEvent struct {
 	message
-	MessageID uint64
-	Timestamp uint64
-	Name      string
-	Payload   string
+	MessageID uint64 `json:"messageID"`
+	Timestamp uint64 `json:"timestamp"`
+	Name      string `json:"name"`
+	Payload   string `json:"payload"`
 }
 
 func (msg *CustomEvent) Encode() []byte {
@@ -1666,9 +1750,9 @@ func (msg *CustomEvent) TypeID() int {
 
 type CSSInsertRule struct {
 	message
-	ID    uint64
-	Rule  string
-	Index uint64
+	ID    uint64 `json:"id"`
+	Rule  string `json:"rule"`
+	Index uint64 `json:"index"`
 }
 
 func (msg *CSSInsertRule) Encode() []byte {
@@ -1702,8 +1786,8 @@ func (msg *CSSInsertRule) TypeID() int {
 
 type CSSDeleteRule struct {
 	message
-	ID    uint64
-	Index uint64
+	ID    uint64 `json:"id"`
+	Index uint64 `json:"index"`
 }
 
 func (msg *CSSDeleteRule) Encode() []byte {
@@ -1736,13 +1820,13 @@ func (msg *CSSDeleteRule) TypeID() int {
 
 type Fetch struct {
 	message
-	Method    string
-	URL       string
-	Request   string
-	Response  string
-	Status    uint64
-	Timestamp uint64
-	Duration  uint64
+	Method    string `json:"method"`
+	URL       string `json:"url"`
+	Request   string `json:"request"`
+	Response  string `json:"response"`
+	Status    uint64 `json:"status"`
+	Timestamp uint64 `json:"timestamp"`
+	Duration  uint64 `json:"duration"`
 }
 
 func (msg *Fetch) Encode() []byte {
@@ -1780,10 +1864,10 @@ func (msg *Fetch) TypeID() int {
 
 type Profiler struct {
 	message
-	Name     string
-	Duration uint64
-	Args     string
-	Result   string
+	Name     string `json:"name"`
+	Duration uint64 `json:"duration"`
+	Args     string `json:"args"`
+	Result   string `json:"result"`
 }
 
 func (msg *Profiler) Encode() []byte {
@@ -1818,8 +1902,8 @@ func (msg *Profiler) TypeID() int {
 
 type OTable struct {
 	message
-	Key   string
-	Value string
+	Key   string `json:"key"`
+	Value string `json:"value"`
 }
 
 func (msg *OTable) Encode() []byte {
@@ -1852,7 +1936,7 @@ func (msg *OTable) TypeID() int {
 
 type StateAction struct {
 	message
-	Type string
+	Type string `json:"type"`
 }
 
 func (msg *StateAction) Encode() []byte {
@@ -1884,9 +1968,9 @@ func (msg *StateAction) TypeID() int {
 
 type StateActionEvent struct {
 	message
-	MessageID uint64
-	Timestamp uint64
-	Type      string
+	MessageID uint64 `json:"messageID"`
+	Timestamp uint64 `json:"timestamp"`
+	Type      string `json:"type"`
 }
 
 func (msg *StateActionEvent) Encode() []byte {
@@ -1920,9 +2004,9 @@ func (msg *StateActionEvent) TypeID() int {
 
 type Redux struct {
 	message
-	Action   string
-	State    string
-	Duration uint64
+	Action   string `json:"action"`
+	State    string `json:"state"`
+	Duration uint64 `json:"duration"`
 }
 
 func (msg *Redux) Encode() []byte {
@@ -1956,8 +2040,8 @@ func (msg *Redux) TypeID() int {
 
 type Vuex struct {
 	message
-	Mutation string
-	State    string
+	Mutation string `json:"mutation"`
+	State    string `json:"state"`
 }
 
 func (msg *Vuex) Encode() []byte {
@@ -1990,8 +2074,8 @@ func (msg *Vuex) TypeID() int {
 
 type MobX struct {
 	message
-	Type    string
-	Payload string
+	Type    string `json:"type"`
+	Payload string `json:"payload"`
 }
 
 func (msg *MobX) Encode() []byte {
@@ -2024,9 +2108,9 @@ func (msg *MobX) TypeID() int {
 
 type NgRx struct {
 	message
-	Action   string
-	State    string
-	Duration uint64
+	Action   string `json:"action"`
+	State    string `json:"state"`
+	Duration uint64 `json:"duration"`
 }
 
 func (msg *NgRx) Encode() []byte {
@@ -2060,10 +2144,10 @@ func (msg *NgRx) TypeID() int {
 
 type GraphQL struct {
 	message
-	OperationKind string
-	OperationName string
-	Variables     string
-	Response      string
+	OperationKind string `json:"operationKind"`
+	OperationName string `json:"operationName"`
+	Variables     string `json:"variables"`
+	Response      string `json:"response"`
 }
 
 func (msg *GraphQL) Encode() []byte {
@@ -2098,10 +2182,10 @@ func (msg *GraphQL) TypeID() int {
 
 type PerformanceTrack struct {
 	message
-	Frames          int64
-	Ticks           int64
-	TotalJSHeapSize uint64
-	UsedJSHeapSize  uint64
+	Frames          int64  `json:"frames"`
+	Ticks           int64  `json:"ticks"`
+	TotalJSHeapSize uint64 `json:"totalJSHeapSize"`
+	UsedJSHeapSize  uint64 `json:"usedJSHeapSize"`
 }
 
 func (msg *PerformanceTrack) Encode() []byte {
@@ -2136,12 +2220,12 @@ func (msg *PerformanceTrack) TypeID() int {
 
 type GraphQLEvent struct {
 	message
-	MessageID     uint64
-	Timestamp     uint64
-	OperationKind string
-	OperationName string
-	Variables     string
-	Response      string
+	MessageID     uint64 `json:"messageID"`
+	Timestamp     uint64 `json:"timestamp"`
+	OperationKind string `json:"operationKind"`
+	OperationName string `json:"operationName"`
+	Variables     string `json:"variables"`
+	Response      string `json:"response"`
 }
 
 func (msg *GraphQLEvent) Encode() []byte {
@@ -2178,14 +2262,14 @@ func (msg *GraphQLEvent) TypeID() int {
 
 type FetchEvent struct {
 	message
-	MessageID uint64
-	Timestamp uint64
-	Method    string
-	URL       string
-	Request   string
-	Response  string
-	Status    uint64
-	Duration  uint64
+	MessageID uint64 `json:"messageID"`
+	Timestamp uint64 `json:"timestamp"`
+	Method    string `json:"method"`
+	URL       string `json:"url"`
+	Request   string `json:"request"`
+	Response  string `json:"response"`
+	Status    uint64 `json:"status"`
+	Duration  uint64 `json:"duration"`
 }
 
 func (msg *FetchEvent) Encode() []byte {
@@ -2224,7 +2308,7 @@ func (msg *FetchEvent) TypeID() int {
 
 type DOMDrop struct {
 	message
-	Timestamp uint64
+	Timestamp uint64 `json:"timestamp"`
 }
 
 func (msg *DOMDrop) Encode() []byte {
@@ -2256,14 +2340,14 @@ func (msg *DOMDrop) TypeID() int {
 
 type ResourceTiming struct {
 	message
-	Timestamp       uint64
-	Duration        uint64
-	TTFB            uint64
-	HeaderSize      uint64
-	EncodedBodySize uint64
-	DecodedBodySize uint64
-	URL             string
-	Initiator       string
+	Timestamp       uint64 `json:"timestamp"`
+	Duration        uint64 `json:"duration"`
+	TTFB            uint64 `json:"ttfb"`
+	HeaderSize      uint64 `json:"headerSize"`
+	EncodedBodySize uint64 `json:"encodedBodySize"`
+	DecodedBodySize uint64 `json:"decodedBodySize"`
+	URL             string `json:"url"`
+	Initiator       string `json:"initiator"`
 }
 
 func (msg *ResourceTiming) Encode() []byte {
@@ -2302,8 +2386,8 @@ func (msg *ResourceTiming) TypeID() int {
 
 type ConnectionInformation struct {
 	message
-	Downlink uint64
-	Type     string
+	Downlink uint64 `json:"downlink"`
+	Type     string `json:"type"`
 }
 
 func (msg *ConnectionInformation) Encode() []byte {
@@ -2336,14 +2420,14 @@ func (msg *ConnectionInformation) TypeID() int {
 
 type SetPageVisibility struct {
 	message
-	hidden bool
+	Hidden bool `json:"hidden"`
 }
 
 func (msg *SetPageVisibility) Encode() []byte {
 	buf := make([]byte, 11)
 	buf[0] = 55
 	p := 1
-	p = WriteBoolean(msg.hidden, buf, p)
+	p = WriteBoolean(msg.Hidden, buf, p)
 	return buf[:p]
 }
 
@@ -2368,20 +2452,20 @@ func (msg *SetPageVisibility) TypeID() int {
 
 type PerformanceTrackAggr struct {
 	message
-	TimestampStart     uint64
-	TimestampEnd       uint64
-	MinFPS             uint64
-	AvgFPS             uint64
-	MaxFPS             uint64
-	MinCPU             uint64
-	AvgCPU             uint64
-	MaxCPU             uint64
-	MinTotalJSHeapSize uint64
-	AvgTotalJSHeapSize uint64
-	MaxTotalJSHeapSize uint64
-	MinUsedJSHeapSize  uint64
-	AvgUsedJSHeapSize  uint64
-	MaxUsedJSHeapSize  uint64
+	TimestampStart     uint64 `json:"timestampStart"`
+	TimestampEnd       uint64 `json:"timestampEnd"`
+	MinFPS             uint64 `json:"minFPS"`
+	AvgFPS             uint64 `json:"avgFPS"`
+	MaxFPS             uint64 `json:"maxFPS"`
+	MinCPU             uint64 `json:"minCPU"`
+	AvgCPU             uint64 `json:"avgCPU"`
+	MaxCPU             uint64 `json:"maxCPU"`
+	MinTotalJSHeapSize uint64 `json:"minTotalJSHeapSize"`
+	AvgTotalJSHeapSize uint64 `json:"avgTotalJSHeapSize"`
+	MaxTotalJSHeapSize uint64 `json:"maxTotalJSHeapSize"`
+	MinUsedJSHeapSize  uint64 `json:"minUsedJSHeapSize"`
+	AvgUsedJSHeapSize  uint64 `json:"avgUsedJSHeapSize"`
+	MaxUsedJSHeapSize  uint64 `json:"maxUsedJSHeapSize"`
 }
 
 func (msg *PerformanceTrackAggr) Encode() []byte {
@@ -2424,15 +2508,51 @@ func (msg *PerformanceTrackAggr) TypeID() int {
 	return 56
 }
 
+type SessionAssociation struct {
+	message
+	SessionID         uint64 `json:"sessionID"`
+	PreviousSessionID uint64 `json:"previousSessionID"`
+	Timestamp         uint64 `json:"timestamp"`
+}
+
+func (msg *SessionAssociation) Encode() []byte {
+	buf := make([]byte, 31)
+	buf[0] = 57
+	p := 1
+	p = WriteUint(msg.SessionID, buf, p)
+	p = WriteUint(msg.PreviousSessionID, buf, p)
+	p = WriteUint(msg.Timestamp, buf, p)
+	return buf[:p]
+}
+
+func (msg *SessionAssociation) EncodeWithIndex() []byte {
+	encoded := msg.Encode()
+	if IsIOSType(msg.TypeID()) {
+		return encoded
+	}
+	data := make([]byte, len(encoded)+8)
+	copy(data[8:], encoded[:])
+	binary.LittleEndian.PutUint64(data[0:], msg.Meta().Index)
+	return data
+}
+
+func (msg *SessionAssociation) Decode() Message {
+	return msg
+}
+
+func (msg *SessionAssociation) TypeID() int {
+	return 57
+}
+
 type LongTask struct {
 	message
-	Timestamp     uint64
-	Duration      uint64
-	Context       uint64
-	ContainerType uint64
-	ContainerSrc  string
-	ContainerId   string
-	ContainerName string
+	Timestamp     uint64 `json:"timestamp"`
+	Duration      uint64 `json:"duration"`
+	Context       uint64 `json:"context"`
+	ContainerType uint64 `json:"containerType"`
+	ContainerSrc  string `json:"containerSrc"`
+	ContainerId   string `json:"containerId"`
+	ContainerName string `json:"containerName"`
 }
 
 func (msg *LongTask) Encode() []byte {
@@ -2470,10 +2590,10 @@ func (msg *LongTask) TypeID() int {
 
 type SetNodeAttributeURLBased struct {
 	message
-	ID      uint64
-	Name    string
-	Value   string
-	BaseURL string
+	ID      uint64 `json:"id"`
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	BaseURL string `json:"baseURL"`
 }
 
 func (msg *SetNodeAttributeURLBased) Encode() []byte {
@@ -2508,9 +2628,9 @@ func (msg *SetNodeAttributeURLBased) TypeID() int {
 
 type SetCSSDataURLBased struct {
 	message
-	ID      uint64
-	Data    string
-	BaseURL string
+	ID      uint64 `json:"id"`
+	Data    string `json:"data"`
+	BaseURL string `json:"baseURL"`
 }
 
 func (msg *SetCSSDataURLBased) Encode() []byte {
@@ -2544,12 +2664,12 @@ func (msg *SetCSSDataURLBased) TypeID() int {
 
 type IssueEvent struct {
 	message
-	MessageID     uint64
-	Timestamp     uint64
-	Type          string
-	ContextString string
-	Context       string
-	Payload       string
+	MessageID     uint64 `json:"messageID"`
+	Timestamp     uint64 `json:"timestamp"`
+	Type          string `json:"type"`
+	ContextString string `json:"contextString"`
+	Context       string `json:"context"`
+	Payload       string `json:"payload"`
 }
 
 func (msg *IssueEvent) Encode() []byte {
@@ -2586,8 +2706,8 @@ func (msg *IssueEvent) TypeID() int {
 
 type TechnicalInfo struct {
 	message
-	Type  string
-	Value string
+	Type  string `json:"type"`
+	Value string `json:"value"`
 }
 
 func (msg *TechnicalInfo) Encode() []byte {
@@ -2620,8 +2740,8 @@ func (msg *TechnicalInfo) TypeID() int {
 
 type CustomIssue struct {
 	message
-	Name    string
-	Payload string
+	Name    string `json:"name"`
+	Payload string `json:"payload"`
 }
 
 func (msg *CustomIssue) Encode() []byte {
@@ -2654,7 +2774,7 @@ func (msg *CustomIssue) TypeID() int {
 
 type AssetCache struct {
 	message
-	URL string
+	URL string `json:"url"`
 }
 
 func (msg *AssetCache) Encode() []byte {
@@ -2686,10 +2806,10 @@ func (msg *AssetCache) TypeID() int {
 
 type CSSInsertRuleURLBased struct {
 	message
-	ID      uint64
-	Rule    string
-	Index   uint64
-	BaseURL string
+	ID      uint64 `json:"id"`
+	Rule    string `json:"rule"`
+	Index   uint64 `json:"index"`
+	BaseURL string `json:"baseURL"`
 }
 
 func (msg *CSSInsertRuleURLBased) Encode() []byte {
@@ -2724,10 +2844,10 @@ func (msg *CSSInsertRuleURLBased) TypeID() int {
 
 type MouseClick struct {
 	message
-	ID             uint64
-	HesitationTime uint64
-	Label          string
-	Selector       string
+	ID             uint64 `json:"id"`
+	HesitationTime uint64 `json:"hesitationTime"`
+	Label          string `json:"label"`
+	Selector       string `json:"selector"`
 }
 
 func (msg *MouseClick) Encode() []byte {
@@ -2762,8 +2882,8 @@ func (msg *MouseClick) TypeID() int {
 
 type CreateIFrameDocument struct {
 	message
-	FrameID uint64
-	ID      uint64
+	FrameID uint64 `json:"frameID"`
+	ID      uint64 `json:"id"`
 }
 
 func (msg *CreateIFrameDocument) Encode() []byte {
@@ -2796,9 +2916,9 @@ func (msg *CreateIFrameDocument) TypeID() int {
 
 type AdoptedSSReplaceURLBased struct {
 	message
-	SheetID uint64
-	Text    string
-	BaseURL string
+	SheetID uint64 `json:"sheetID"`
+	Text    string `json:"text"`
+	BaseURL string `json:"baseURL"`
 }
 
 func (msg *AdoptedSSReplaceURLBased) Encode() []byte {
@@ -2832,8 +2952,8 @@ func (msg *AdoptedSSReplaceURLBased) TypeID() int {
 
 type AdoptedSSReplace struct {
 	message
-	SheetID uint64
-	Text    string
+	SheetID uint64 `json:"sheetID"`
+	Text    string `json:"text"`
 }
 
 func (msg *AdoptedSSReplace) Encode() []byte {
@@ -2866,10 +2986,10 @@ func (msg *AdoptedSSReplace) TypeID() int {
 
 type AdoptedSSInsertRuleURLBased struct {
 	message
-	SheetID uint64
-	Rule    string
-	Index   uint64
-	BaseURL string
+	SheetID uint64 `json:"sheetID"`
+	Rule    string `json:"rule"`
+	Index   uint64 `json:"index"`
+	BaseURL string `json:"baseURL"`
 }
 
 func (msg *AdoptedSSInsertRuleURLBased) Encode() []byte {
@@ -2904,9 +3024,9 @@ func (msg *AdoptedSSInsertRuleURLBased) TypeID() int {
 
 type AdoptedSSInsertRule struct {
 	message
-	SheetID uint64
-	Rule    string
-	Index   uint64
+	SheetID uint64 `json:"sheetID"`
+	Rule    string `json:"rule"`
+	Index   uint64 `json:"index"`
 }
 
 func (msg *AdoptedSSInsertRule) Encode() []byte {
@@ -2940,8 +3060,8 @@ func (msg *AdoptedSSInsertRule) TypeID() int {
 
 type AdoptedSSDeleteRule struct {
 	message
-	SheetID uint64
-	Index   uint64
+	SheetID uint64 `json:"sheetID"`
+	Index   uint64 `json:"index"`
 }
 
 func (msg *AdoptedSSDeleteRule) Encode() []byte {
@@ -2974,8 +3094,8 @@ func (msg *AdoptedSSDeleteRule) TypeID() int {
 
 type AdoptedSSAddOwner struct {
 	message
-	SheetID uint64
-	ID      uint64
+	SheetID uint64 `json:"sheetID"`
+	ID      uint64 `json:"id"`
 }
 
 func (msg *AdoptedSSAddOwner) Encode() []byte {
@@ -3008,8 +3128,8 @@ func (msg *AdoptedSSAddOwner) TypeID() int {
 
 type AdoptedSSRemoveOwner struct {
 	message
-	SheetID uint64
-	ID      uint64
+	SheetID uint64 `json:"sheetID"`
+	ID      uint64 `json:"id"`
 }
 
 func (msg *AdoptedSSRemoveOwner) Encode() []byte {
@@ -3042,8 +3162,8 @@ func (msg *AdoptedSSRemoveOwner) TypeID() int {
 
 type Zustand struct {
 	message
-	Mutation string
-	State    string
+	Mutation string `json:"mutation"`
+	State    string `json:"state"`
 }
 
 func (msg *Zustand) Encode() []byte {
@@ -3076,9 +3196,9 @@ func (msg *Zustand) TypeID() int {
 
 type IOSBatchMeta struct {
 	message
-	Timestamp  uint64
-	Length     uint64
-	FirstIndex uint64
+	Timestamp  uint64 `json:"timestamp"`
+	Length     uint64 `json:"length"`
+	FirstIndex uint64 `json:"firstIndex"`
 }
 
 func (msg *IOSBatchMeta) Encode() []byte {
@@ -3112,16 +3232,16 @@ func (msg *IOSBatchMeta) TypeID() int {
 
 type IOSSessionStart struct {
 	message
-	Timestamp      uint64
-	ProjectID      uint64
-	TrackerVersion string
-	RevID          string
-	UserUUID       string
-	UserOS         string
-	UserOSVersion  string
-	UserDevice     string
-	UserDeviceType string
-	UserCountry    string
+	Timestamp      uint64 `json:"timestamp"`
+	ProjectID      uint64 `json:"projectID"`
+	TrackerVersion string `json:"trackerVersion"`
+	RevID          string `json:"revID"`
+	UserUUID       string `json:"userUUID"`
+	UserOS         string `json:"userOS"`
+	UserOSVersion  string `json:"userOSVersion"`
+	UserDevice     string `json:"userDevice"`
+	UserDeviceType string `json:"userDeviceType"`
+	UserCountry    string `json:"userCountry"`
 }
 
 func (msg *IOSSessionStart) Encode() []byte {
@@ -3160,9 +3280,59 @@ func (msg *IOSSessionStart) TypeID() int {
 	return 90
 }
 
+type AndroidSessionStart struct {
+	message
+	Timestamp      uint64 `json:"timestamp"`
+	ProjectID      uint64 `json:"projectID"`
+	TrackerVersion string `json:"trackerVersion"`
+	RevID          string `json:"revID"`
+	UserUUID       string `json:"userUUID"`
+	UserOS         string `json:"userOS"`
+	UserOSVersion  string `json:"userOSVersion"`
+	UserDevice     string `json:"userDevice"`
+	UserDeviceType string `json:"userDeviceType"`
+	UserCountry    string `json:"userCountry"`
+}
+
+func (msg *AndroidSessionStart) Encode() []byte {
+	buf := make([]byte, 101+len(msg.TrackerVersion)+len(msg.RevID)+len(msg.UserUUID)+len(msg.UserOS)+len(msg.UserOSVersion)+len(msg.UserDevice)+len(msg.UserDeviceType)+len(msg.UserCountry))
+	buf[0] = 112
+	p := 1
+	p = WriteUint(msg.Timestamp, buf, p)
+	p = WriteUint(msg.ProjectID, buf, p)
+	p = WriteString(msg.TrackerVersion, buf, p)
+	p = WriteString(msg.RevID, buf, p)
+	p = WriteString(msg.UserUUID, buf, p)
+	p = WriteString(msg.UserOS, buf, p)
+	p = WriteString(msg.UserOSVersion, buf, p)
+	p = WriteString(msg.UserDevice, buf, p)
+	p = WriteString(msg.UserDeviceType, buf, p)
+	p = WriteString(msg.UserCountry, buf, p)
+	return buf[:p]
+}
+
+func (msg *AndroidSessionStart) EncodeWithIndex() []byte {
+	encoded := msg.Encode()
+	if IsIOSType(msg.TypeID()) {
+		return encoded
+	}
+	data := make([]byte, len(encoded)+8)
+	copy(data[8:], encoded[:])
+	binary.LittleEndian.PutUint64(data[0:], msg.Meta().Index)
+	return data
+}
+
+func (msg *AndroidSessionStart) Decode() Message {
+	return msg
+}
+
+func (msg *AndroidSessionStart) TypeID() int {
+	return 112
+}
+
 type IOSSessionEnd struct {
 	message
-	Timestamp uint64
+	Timestamp uint64 `json:"timestamp"`
 }
 
 func (msg *IOSSessionEnd) Encode() []byte {
@@ -3194,10 +3364,10 @@ func (msg *IOSSessionEnd) TypeID() int {
 
 type IOSMetadata struct {
 	message
-	Timestamp uint64
-	Length    uint64
-	Key       string
-	Value     string
+	Timestamp uint64 `json:"timestamp"`
+	Length    uint64 `json:"length"`
+	Key       string `json:"key"`
+	Value     string `json:"value"`
 }
 
 func (msg *IOSMetadata) Encode() []byte {
@@ -3232,10 +3402,10 @@ func (msg *IOSMetadata) TypeID() int {
 
 type IOSCustomEvent struct {
 	message
-	Timestamp uint64
-	Length    uint64
-	Name      string
-	Payload   string
+	Timestamp uint64 `json:"timestamp"`
+	Length    uint64 `json:"length"`
+	Name      string `json:"name"`
+	Payload   string `json:"payload"`
 }
 
 func (msg *IOSCustomEvent) Encode() []byte {
@@ -3270,9 +3440,9 @@ func (msg *IOSCustomEvent) TypeID() int {
 
 type IOSUserID struct {
 	message
-	Timestamp uint64
-	Length    uint64
-	Value     string
+	Timestamp uint64 `json:"timestamp"`
+	Length    uint64 `json:"length"`
+	Value     string `json:"value"`
 }
 
 func (msg *IOSUserID) Encode() []byte {
@@ -3306,9 +3476,9 @@ func (msg *IOSUserID) TypeID() int {
 
 type IOSUserAnonymousID struct {
 	message
-	Timestamp uint64
-	Length    uint64
-	Value     string
+	Timestamp uint64 `json:"timestamp"`
+	Length    uint64 `json:"length"`
+	Value     string `json:"value"`
 }
 
 func (msg *IOSUserAnonymousID) Encode() []byte {
@@ -3342,12 +3512,12 @@ func (msg *IOSUserAnonymousID) TypeID() int {
 
 type IOSScreenChanges struct {
 	message
-	Timestamp uint64
-	Length    uint64
-	X         uint64
-	Y         uint64
-	Width     uint64
-	Height    uint64
+	Timestamp uint64 `json:"timestamp"`
+	Length    uint64 `json:"length"`
+	X         uint64 `json:"x"`
+	Y         uint64 `json:"y"`
+	Width     uint64 `json:"width"`
+	Height    uint64 `json:"height"`
 }
 
 func (msg *IOSScreenChanges) Encode() []byte {
@@ -3384,11 +3554,11 @@ func (msg *IOSScreenChanges) TypeID() int {
 
 type IOSCrash struct {
 	message
-	Timestamp  uint64
-	Length     uint64
-	Name       string
-	Reason     string
-	Stacktrace string
+	Timestamp  uint64 `json:"timestamp"`
+	Length     uint64 `json:"length"`
+	Name       string `json:"name"`
+	Reason     string `json:"reason"`
+	Stacktrace string `json:"stacktrace"`
 }
 
 func (msg *IOSCrash) Encode() []byte {
@@ -3424,10 +3594,10 @@ func (msg *IOSCrash) TypeID() int {
 
 type IOSScreenEnter struct {
 	message
-	Timestamp uint64
-	Length    uint64
-	Title     string
-	ViewName  string
+	Timestamp uint64 `json:"timestamp"`
+	Length    uint64 `json:"length"`
+	Title     string `json:"title"`
+	ViewName  string `json:"viewName"`
 }
 
 func (msg *IOSScreenEnter) Encode() []byte {
@@ -3462,10 +3632,10 @@ func (msg *IOSScreenEnter) TypeID() int {
 
 type IOSScreenLeave struct {
 	message
-	Timestamp uint64
-	Length    uint64
-	Title     string
-	ViewName  string
+	Timestamp uint64 `json:"timestamp"`
+	Length    uint64 `json:"length"`
+	Title     string `json:"title"`
+	ViewName  string `json:"viewName"`
 }
 
 func (msg *IOSScreenLeave) Encode() []byte {
@@ -3500,11 +3670,11 @@ func (msg *IOSScreenLeave) TypeID() int {
 
 type IOSClickEvent struct {
 	message
-	Timestamp uint64
-	Length    uint64
-	Label     string
-	X         uint64
-	Y         uint64
+	Timestamp uint64 `json:"timestamp"`
+	Length    uint64 `json:"length"`
+	Label     string `json:"label"`
+	X         uint64 `json:"x"`
+	Y         uint64 `json:"y"`
 }
 
 func (msg *IOSClickEvent) Encode() []byte {
@@ -3540,11 +3710,11 @@ func (msg *IOSClickEvent) TypeID() int {
 
 type IOSInputEvent struct {
 	message
-	Timestamp   uint64
-	Length      uint64
-	Value       string
-	ValueMasked bool
-	Label       string
+	Timestamp   uint64 `json:"timestamp"`
+	Length      uint64 `json:"length"`
+	Value       string `json:"value"`
+	ValueMasked bool   `json:"valueMasked"`
+	Label       string `json:"label"`
 }
 
 func (msg *IOSInputEvent) Encode() []byte {
@@ -3580,10 +3750,10 @@ func (msg *IOSInputEvent) TypeID() int {
 
 type IOSPerformanceEvent struct {
 	message
-	Timestamp uint64
-	Length    uint64
-	Name      string
-	Value     uint64
+	Timestamp uint64 `json:"timestamp"`
+	Length    uint64 `json:"length"`
+	Name      string `json:"name"`
+	Value     uint64 `json:"value"`
 }
 
 func (msg *IOSPerformanceEvent) Encode() []byte {
@@ -3618,10 +3788,10 @@ func (msg *IOSPerformanceEvent) TypeID() int {
 
 type IOSLog struct {
 	message
-	Timestamp uint64
-	Length    uint64
-	Severity  string
-	Content   string
+	Timestamp uint64 `json:"timestamp"`
+	Length    uint64 `json:"length"`
+	Severity  string `json:"severity"`
+	Content   string `json:"content"`
 }
 
 func (msg *IOSLog) Encode() []byte {
@@ -3656,9 +3826,9 @@ func (msg *IOSLog) TypeID() int {
 
 type IOSInternalError struct {
 	message
-	Timestamp uint64
-	Length    uint64
-	Content   string
+	Timestamp uint64 `json:"timestamp"`
+	Length    uint64 `json:"length"`
+	Content   string `json:"content"`
 }
 
 func (msg *IOSInternalError) Encode() []byte {
@@ -3692,15 +3862,15 @@ func (msg *IOSInternalError) TypeID() int {
 
 type IOSNetworkCall struct {
 	message
-	Timestamp uint64
-	Length    uint64
-	Duration  uint64
-	Headers   string
-	Body      string
-	URL       string
-	Success   bool
-	Method    string
-	Status    uint64
+	Timestamp uint64 `json:"timestamp"`
+	Length    uint64 `json:"length"`
+	Duration  uint64 `json:"duration"`
+	Headers   string `json:"headers"`
+	Body      string `json:"body"`
+	URL       string `json:"url"`
+	Success   bool   `json:"success"`
+	Method    string `json:"method"`
+	Status    uint64 `json:"status"`
 }
 
 func (msg *IOSNetworkCall) Encode() []byte {
@@ -3740,20 +3910,20 @@ func (msg *IOSNetworkCall) TypeID() int {
 
 type IOSPerformanceAggregated struct {
 	message
-	TimestampStart uint64
-	TimestampEnd   uint64
-	MinFPS         uint64
-	AvgFPS         uint64
-	MaxFPS         uint64
-	MinCPU         uint64
-	AvgCPU         uint64
-	MaxCPU         uint64
-	MinMemory      uint64
-	AvgMemory      uint64
-	MaxMemory      uint64
-	MinBattery     uint64
-	AvgBattery     uint64
-	MaxBattery     uint64
+	TimestampStart uint64 `json:"timestampStart"`
+	TimestampEnd   uint64 `json:"timestampEnd"`
+	MinFPS         uint64 `json:"minFPS"`
+	AvgFPS         uint64 `json:"avgFPS"`
+	MaxFPS         uint64 `json:"maxFPS"`
+	MinCPU         uint64 `json:"minCPU"`
+	AvgCPU         uint64 `json:"avgCPU"`
+	MaxCPU         uint64 `json:"maxCPU"`
+	MinMemory      uint64 `json:"minMemory"`
+	AvgMemory      uint64 `json:"avgMemory"`
+	MaxMemory      uint64 `json:"maxMemory"`
+	MinBattery     uint64 `json:"minBattery"`
+	AvgBattery     uint64 `json:"avgBattery"`
+	MaxBattery     uint64 `json:"maxBattery"`
 }
 
 func (msg *IOSPerformanceAggregated) Encode() []byte {
@@ -3798,11 +3968,11 @@ func (msg *IOSPerformanceAggregated) TypeID() int {
 
 type IOSIssueEvent struct {
 	message
-	Timestamp     uint64
-	Type          string
-	ContextString string
-	Context       string
-	Payload       string
+	Timestamp     uint64 `json:"timestamp"`
+	Type          string `json:"type"`
+	ContextString string `json:"contextString"`
+	Context       string `json:"context"`
+	Payload       string `json:"payload"`
 }
 
 func (msg *IOSIssueEvent) Encode() []byte {
@@ -0,0 +1,53 @@
+package messages
+
+import (
+	"bytes"
+	"errors"
+)
+
+// SplitBatch splits the batch into chunks not larger than chunkSize. Every chunk starts with PartitionedMessage
+// followed by the next part of the batch, so the batch can be restored by concatenation of parts in order.
+func SplitBatch(batch []byte, chunkSize int) ([][]byte, error) {
+	// Header size is known only after the number of parts, so reserve the maximum for it
+	partSize := chunkSize - 21
+	if partSize <= 0 {
+		return nil, errors.New("chunk size is too small")
+	}
+	total := uint64((len(batch) + partSize - 1) / partSize)
+	chunks := make([][]byte, 0, total)
+	for no := uint64(0); no < total; no++ {
+		start := int(no) * partSize
+		end := start + partSize
+		if end > len(batch) {
+			end = len(batch)
+		}
+		header := Encode(&PartitionedMessage{PartNo: no, PartTotal: total})
+		chunk := make([]byte, 0, len(header)+end-start)
+		chunk = append(chunk, header...)
+		chunks = append(chunks, append(chunk, batch[start:end]...))
+	}
+	return chunks, nil
+}
+
+// IsPartitioned returns true if the queue message is a chunk made by SplitBatch.
+// Trackers don't send PartitionedMessage, so a batch never starts with it.
+func IsPartitioned(data []byte) bool {
+	return len(data) > 0 && data[0] == MsgPartitionedMessage
+}
+
+// ReadPartition returns the header and the part of the batch from the chunk made by SplitBatch
+func ReadPartition(chunk []byte) (*PartitionedMessage, []byte, error) {
+	if !IsPartitioned(chunk) {
+		return nil, nil, errors.New("not a partitioned message")
+	}
+	reader := bytes.NewReader(chunk[1:])
+	msg, err := DecodePartitionedMessage(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	header := msg.(*PartitionedMessage)
+	if header.PartTotal == 0 || header.PartNo >= header.PartTotal {
+		return nil, nil, errors.New("wrong part number")
+	}
+	return header, chunk[len(chunk)-reader.Len():], nil
+}
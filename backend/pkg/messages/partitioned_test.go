@@ -0,0 +1,86 @@
+package messages
+
+import (
+	"bytes"
+	"testing"
+)
+
+func testBatch(size int) []byte {
+	batch := make([]byte, size)
+	for i := range batch {
+		batch[i] = byte(i % 251)
+	}
+	return batch
+}
+
+func TestSplitBatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		chunkSize int
+		parts     int
+	}{
+		{"one part", 100, 1000, 1},
+		{"exact parts", 2 * (1000 - 21), 1000, 2},
+		{"last part is shorter", 2500, 1000, 3},
+		{"many parts", 100000, 1024, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := testBatch(tt.size)
+			chunks, err := SplitBatch(batch, tt.chunkSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(chunks) != tt.parts {
+				t.Fatalf("got %d chunks, want %d", len(chunks), tt.parts)
+			}
+			var restored []byte
+			for no, chunk := range chunks {
+				if len(chunk) > tt.chunkSize {
+					t.Errorf("chunk %d is %d bytes, limit is %d", no, len(chunk), tt.chunkSize)
+				}
+				if !IsPartitioned(chunk) {
+					t.Fatalf("chunk %d isn't partitioned", no)
+				}
+				header, part, err := ReadPartition(chunk)
+				if err != nil {
+					t.Fatalf("chunk %d: %s", no, err)
+				}
+				if header.PartNo != uint64(no) || header.PartTotal != uint64(tt.parts) {
+					t.Errorf("chunk %d has header %d/%d", no, header.PartNo, header.PartTotal)
+				}
+				restored = append(restored, part...)
+			}
+			if !bytes.Equal(restored, batch) {
+				t.Error("restored batch differs from the original one")
+			}
+		})
+	}
+}
+
+func TestSplitBatchSmallChunk(t *testing.T) {
+	if _, err := SplitBatch(testBatch(100), 21); err == nil {
+		t.Error("expected error for the chunk without space for the data")
+	}
+}
+
+func TestReadPartitionErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		chunk []byte
+	}{
+		{"empty", nil},
+		{"usual batch", Encode(&BatchMetadata{Version: 1})},
+		{"truncated header", []byte{MsgPartitionedMessage}},
+		{"zero total", Encode(&PartitionedMessage{PartNo: 0, PartTotal: 0})},
+		{"part out of range", Encode(&PartitionedMessage{PartNo: 2, PartTotal: 2})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ReadPartition(tt.chunk); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
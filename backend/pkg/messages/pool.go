@@ -0,0 +1,44 @@
+package messages
+
+import "sync"
+
+// messagePools keep decoded messages by type ID to reuse them in hot consumers
+var messagePools [256]sync.Pool
+
+func init() {
+	for id := range messageNames {
+		id := id
+		messagePools[id].New = func() interface{} {
+			return newMessage(id)
+		}
+	}
+}
+
+// getMessage returns an empty or a released message of the given type
+func getMessage(id int) Message {
+	return messagePools[id].Get().(Message)
+}
+
+// ReleaseMessage returns the decoded message to the pool. The message must not be used after that.
+func ReleaseMessage(msg Message) {
+	if msg == nil {
+		return
+	}
+	id := msg.TypeID()
+	if id < 0 || id >= len(messagePools) || messagePools[id].New == nil {
+		return
+	}
+	if _, ok := msg.(*RawMessage); ok {
+		return
+	}
+	// All the fields are overwritten by decoding, but meta is set only by iterator
+	*msg.Meta() = message{}
+	messagePools[id].Put(msg)
+}
+
+// decoderReaders keep readers for decoding of raw messages
+var decoderReaders = sync.Pool{
+	New: func() interface{} {
+		return newDecoderReader(nil, &DefaultDecoderOptions)
+	},
+}
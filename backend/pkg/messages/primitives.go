@@ -9,6 +9,10 @@ import (
 )
 
 func ReadByte(reader io.Reader) (byte, error) {
+	// Avoid allocation of the temporary buffer for the in-memory readers
+	if r, ok := reader.(io.ByteReader); ok {
+		return r.ReadByte()
+	}
 	p := make([]byte, 1)
 	_, err := io.ReadFull(reader, p)
 	if err != nil {
@@ -22,6 +26,9 @@ func ReadData(reader io.Reader) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkLength(reader, n); err != nil {
+		return nil, err
+	}
 	p := make([]byte, n)
 	_, err = io.ReadFull(reader, p)
 	if err != nil {
@@ -64,12 +71,11 @@ func ReadInt(reader io.Reader) (int64, error) {
 }
 
 func ReadBoolean(reader io.Reader) (bool, error) {
-	p := make([]byte, 1)
-	_, err := io.ReadFull(reader, p)
+	b, err := ReadByte(reader)
 	if err != nil {
 		return false, err
 	}
-	return p[0] == 1, nil
+	return b == 1, nil
 }
 
 func ReadString(reader io.Reader) (string, error) {
@@ -77,8 +83,8 @@ func ReadString(reader io.Reader) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if l > 10e6 {
-		return "", errors.New("Too long string")
+	if err := checkLength(reader, l); err != nil {
+		return "", err
 	}
 	buf := make([]byte, l)
 	_, err = io.ReadFull(reader, buf)
@@ -146,26 +152,30 @@ func WriteJson(v interface{}, buf []byte, p int) int {
 	return WriteData(data, buf, p)
 }
 
+const (
+	// Size of messages in batches of version 1 is written in sizeBytes bytes
+	sizeBytes = 3
+	maxSize   = 1<<(8*sizeBytes) - 1
+)
+
 func WriteSize(size uint64, buf []byte, p int) {
 	var m uint64 = 255
-	for i := 0; i < 3; i++ {
+	for i := 0; i < sizeBytes; i++ {
 		buf[p+i] = byte(size & m)
 		size = size >> 8
 	}
-	fmt.Println(buf)
 }
 
 func ReadSize(reader io.Reader) (uint64, error) {
-	buf := make([]byte, 3)
-	n, err := io.ReadFull(reader, buf)
-	if err != nil {
-		return 0, err
-	}
-	if n != 3 {
-		return 0, fmt.Errorf("read only %d of 3 size bytes", n)
-	}
 	var size uint64
-	for i, b := range buf {
+	for i := 0; i < sizeBytes; i++ {
+		b, err := ReadByte(reader)
+		if err != nil {
+			if err == io.EOF && i > 0 {
+				return 0, fmt.Errorf("read only %d of %d size bytes", i, sizeBytes)
+			}
+			return 0, err
+		}
 		size += uint64(b) << (8 * i)
 	}
 	return size, nil
@@ -1,57 +1,42 @@
 package messages
 
 import (
-	"bytes"
 	"encoding/binary"
-	"io"
 	"log"
 )
 
-// RawMessage is a not decoded message
+// RawMessage is a not decoded message, its body points to the batch data
 type RawMessage struct {
-	tp      uint64
-	size    uint64
-	data    []byte
-	reader  *bytes.Reader
-	meta    *message
-	encoded bool
-	skipped *bool
+	tp   uint64
+	size uint64
+	body []byte
+	opts *DecoderOptions
+	meta *message
 }
 
+// Encode returns a copy of the message, so it can be kept after the batch is processed
 func (m *RawMessage) Encode() []byte {
-	if m.encoded {
-		return m.data
-	}
-	m.data = make([]byte, m.size+1)
-	m.data[0] = uint8(m.tp)
-	m.encoded = true
-	*m.skipped = false
-	_, err := io.ReadFull(m.reader, m.data[1:])
-	if err != nil {
-		log.Printf("message encode err: %s", err)
-		return nil
-	}
-	return m.data
+	data := make([]byte, m.size+1)
+	data[0] = uint8(m.tp)
+	copy(data[1:], m.body)
+	return data
 }
 
 func (m *RawMessage) EncodeWithIndex() []byte {
-	if !m.encoded {
-		m.Encode()
-	}
-	if IsIOSType(int(m.tp)) {
-		return m.data
-	}
-	data := make([]byte, len(m.data)+8)
-	copy(data[8:], m.data[:])
-	binary.LittleEndian.PutUint64(data[0:], m.Meta().Index)
-	return data
+	return AppendWithIndex(make([]byte, 0, m.size+9), m)
 }
 
 func (m *RawMessage) Decode() Message {
-	if !m.encoded {
-		m.Encode()
+	opts := m.opts
+	if opts == nil {
+		opts = &DefaultDecoderOptions
 	}
-	msg, err := ReadMessage(m.tp, bytes.NewReader(m.data[1:]))
+	reader := decoderReaders.Get().(*decoderReader)
+	reader.Reset(m.body)
+	reader.opts = opts
+	msg, err := ReadMessage(m.tp, reader)
+	reader.Reset(nil)
+	decoderReaders.Put(reader)
 	if err != nil {
 		log.Printf("decode err: %s", err)
 		return nil
@@ -67,3 +52,17 @@ func (m *RawMessage) TypeID() int {
 func (m *RawMessage) Meta() *message {
 	return m.meta
 }
+
+// AppendWithIndex appends the message in the session file format to dst without intermediate copies
+func AppendWithIndex(dst []byte, msg Message) []byte {
+	if !IsIOSType(msg.TypeID()) {
+		var index [8]byte
+		binary.LittleEndian.PutUint64(index[:], msg.Meta().Index)
+		dst = append(dst, index[:]...)
+	}
+	if raw, ok := msg.(*RawMessage); ok {
+		dst = append(dst, uint8(raw.tp))
+		return append(dst, raw.body...)
+	}
+	return append(dst, msg.Encode()...)
+}
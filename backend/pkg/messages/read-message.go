@@ -8,7 +8,7 @@ import (
 
 func DecodeBatchMeta(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &BatchMeta{}
+	msg := getMessage(80).(*BatchMeta)
 	if msg.PageNo, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -23,7 +23,7 @@ func DecodeBatchMeta(reader io.Reader) (Message, error) {
 
 func DecodeBatchMetadata(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &BatchMetadata{}
+	msg := getMessage(81).(*BatchMetadata)
 	if msg.Version, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -44,7 +44,7 @@ func DecodeBatchMetadata(reader io.Reader) (Message, error) {
 
 func DecodePartitionedMessage(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &PartitionedMessage{}
+	msg := getMessage(82).(*PartitionedMessage)
 	if msg.PartNo, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -56,7 +56,7 @@ func DecodePartitionedMessage(reader io.Reader) (Message, error) {
 
 func DecodeTimestamp(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &Timestamp{}
+	msg := getMessage(0).(*Timestamp)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -65,7 +65,7 @@ func DecodeTimestamp(reader io.Reader) (Message, error) {
 
 func DecodeSessionStart(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SessionStart{}
+	msg := getMessage(1).(*SessionStart)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -117,9 +117,45 @@ func DecodeSessionStart(reader io.Reader) (Message, error) {
 	return msg, err
 }
 
+func DecodeSessionGeo(reader io.Reader) (Message, error) {
+	var err error = nil
+	msg := getMessage(83).(*SessionGeo)
+	if msg.UserRegion, err = ReadString(reader); err != nil {
+		return nil, err
+	}
+	if msg.UserCity, err = ReadString(reader); err != nil {
+		return nil, err
+	}
+	if msg.UserLatitude, err = ReadInt(reader); err != nil {
+		return nil, err
+	}
+	if msg.UserLongitude, err = ReadInt(reader); err != nil {
+		return nil, err
+	}
+	if msg.UserTimezone, err = ReadString(reader); err != nil {
+		return nil, err
+	}
+	if msg.UserASN, err = ReadUint(reader); err != nil {
+		return nil, err
+	}
+	if msg.UserASNOrg, err = ReadString(reader); err != nil {
+		return nil, err
+	}
+	return msg, err
+}
+
+func DecodeSessionClockSkew(reader io.Reader) (Message, error) {
+	var err error = nil
+	msg := getMessage(84).(*SessionClockSkew)
+	if msg.ClockSkew, err = ReadInt(reader); err != nil {
+		return nil, err
+	}
+	return msg, err
+}
+
 func DecodeSessionEnd(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SessionEnd{}
+	msg := getMessage(3).(*SessionEnd)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -128,7 +164,7 @@ func DecodeSessionEnd(reader io.Reader) (Message, error) {
 
 func DecodeSetPageLocation(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetPageLocation{}
+	msg := getMessage(4).(*SetPageLocation)
 	if msg.URL, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -143,7 +179,7 @@ func DecodeSetPageLocation(reader io.Reader) (Message, error) {
 
 func DecodeSetViewportSize(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetViewportSize{}
+	msg := getMessage(5).(*SetViewportSize)
 	if msg.Width, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -155,7 +191,7 @@ func DecodeSetViewportSize(reader io.Reader) (Message, error) {
 
 func DecodeSetViewportScroll(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetViewportScroll{}
+	msg := getMessage(6).(*SetViewportScroll)
 	if msg.X, err = ReadInt(reader); err != nil {
 		return nil, err
 	}
@@ -167,21 +203,21 @@ func DecodeSetViewportScroll(reader io.Reader) (Message, error) {
 
 func DecodeCreateDocument(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &CreateDocument{}
+	msg := getMessage(7).(*CreateDocument)
 
 	return msg, err
 }
 
 func DecodeCreateElementNode(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &CreateElementNode{}
+	msg := getMessage(8).(*CreateElementNode)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
 	if msg.ParentID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
-	if msg.index, err = ReadUint(reader); err != nil {
+	if msg.Index, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
 	if msg.Tag, err = ReadString(reader); err != nil {
@@ -195,7 +231,7 @@ func DecodeCreateElementNode(reader io.Reader) (Message, error) {
 
 func DecodeCreateTextNode(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &CreateTextNode{}
+	msg := getMessage(9).(*CreateTextNode)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -210,7 +246,7 @@ func DecodeCreateTextNode(reader io.Reader) (Message, error) {
 
 func DecodeMoveNode(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &MoveNode{}
+	msg := getMessage(10).(*MoveNode)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -225,7 +261,7 @@ func DecodeMoveNode(reader io.Reader) (Message, error) {
 
 func DecodeRemoveNode(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &RemoveNode{}
+	msg := getMessage(11).(*RemoveNode)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -234,7 +270,7 @@ func DecodeRemoveNode(reader io.Reader) (Message, error) {
 
 func DecodeSetNodeAttribute(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetNodeAttribute{}
+	msg := getMessage(12).(*SetNodeAttribute)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -249,7 +285,7 @@ func DecodeSetNodeAttribute(reader io.Reader) (Message, error) {
 
 func DecodeRemoveNodeAttribute(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &RemoveNodeAttribute{}
+	msg := getMessage(13).(*RemoveNodeAttribute)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -261,7 +297,7 @@ func DecodeRemoveNodeAttribute(reader io.Reader) (Message, error) {
 
 func DecodeSetNodeData(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetNodeData{}
+	msg := getMessage(14).(*SetNodeData)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -273,7 +309,7 @@ func DecodeSetNodeData(reader io.Reader) (Message, error) {
 
 func DecodeSetCSSData(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetCSSData{}
+	msg := getMessage(15).(*SetCSSData)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -285,7 +321,7 @@ func DecodeSetCSSData(reader io.Reader) (Message, error) {
 
 func DecodeSetNodeScroll(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetNodeScroll{}
+	msg := getMessage(16).(*SetNodeScroll)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -300,7 +336,7 @@ func DecodeSetNodeScroll(reader io.Reader) (Message, error) {
 
 func DecodeSetInputTarget(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetInputTarget{}
+	msg := getMessage(17).(*SetInputTarget)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -312,7 +348,7 @@ func DecodeSetInputTarget(reader io.Reader) (Message, error) {
 
 func DecodeSetInputValue(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetInputValue{}
+	msg := getMessage(18).(*SetInputValue)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -327,7 +363,7 @@ func DecodeSetInputValue(reader io.Reader) (Message, error) {
 
 func DecodeSetInputChecked(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetInputChecked{}
+	msg := getMessage(19).(*SetInputChecked)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -339,7 +375,7 @@ func DecodeSetInputChecked(reader io.Reader) (Message, error) {
 
 func DecodeMouseMove(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &MouseMove{}
+	msg := getMessage(20).(*MouseMove)
 	if msg.X, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -351,7 +387,7 @@ func DecodeMouseMove(reader io.Reader) (Message, error) {
 
 func DecodeMouseClickDepricated(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &MouseClickDepricated{}
+	msg := getMessage(21).(*MouseClickDepricated)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -366,7 +402,7 @@ func DecodeMouseClickDepricated(reader io.Reader) (Message, error) {
 
 func DecodeConsoleLog(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &ConsoleLog{}
+	msg := getMessage(22).(*ConsoleLog)
 	if msg.Level, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -378,7 +414,7 @@ func DecodeConsoleLog(reader io.Reader) (Message, error) {
 
 func DecodePageLoadTiming(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &PageLoadTiming{}
+	msg := getMessage(23).(*PageLoadTiming)
 	if msg.RequestStart, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -411,7 +447,7 @@ func DecodePageLoadTiming(reader io.Reader) (Message, error) {
 
 func DecodePageRenderTiming(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &PageRenderTiming{}
+	msg := getMessage(24).(*PageRenderTiming)
 	if msg.SpeedIndex, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -426,7 +462,7 @@ func DecodePageRenderTiming(reader io.Reader) (Message, error) {
 
 func DecodeJSException(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &JSException{}
+	msg := getMessage(25).(*JSException)
 	if msg.Name, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -441,7 +477,7 @@ func DecodeJSException(reader io.Reader) (Message, error) {
 
 func DecodeIntegrationEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IntegrationEvent{}
+	msg := getMessage(26).(*IntegrationEvent)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -462,7 +498,7 @@ func DecodeIntegrationEvent(reader io.Reader) (Message, error) {
 
 func DecodeRawCustomEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &RawCustomEvent{}
+	msg := getMessage(27).(*RawCustomEvent)
 	if msg.Name, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -474,7 +510,7 @@ func DecodeRawCustomEvent(reader io.Reader) (Message, error) {
 
 func DecodeUserID(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &UserID{}
+	msg := getMessage(28).(*UserID)
 	if msg.ID, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -483,7 +519,7 @@ func DecodeUserID(reader io.Reader) (Message, error) {
 
 func DecodeUserAnonymousID(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &UserAnonymousID{}
+	msg := getMessage(29).(*UserAnonymousID)
 	if msg.ID, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -492,7 +528,7 @@ func DecodeUserAnonymousID(reader io.Reader) (Message, error) {
 
 func DecodeMetadata(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &Metadata{}
+	msg := getMessage(30).(*Metadata)
 	if msg.Key, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -504,7 +540,7 @@ func DecodeMetadata(reader io.Reader) (Message, error) {
 
 func DecodePageEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &PageEvent{}
+	msg := getMessage(31).(*PageEvent)
 	if msg.MessageID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -561,7 +597,7 @@ func DecodePageEvent(reader io.Reader) (Message, error) {
 
 func DecodeInputEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &InputEvent{}
+	msg := getMessage(32).(*InputEvent)
 	if msg.MessageID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -582,7 +618,7 @@ func DecodeInputEvent(reader io.Reader) (Message, error) {
 
 func DecodeClickEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &ClickEvent{}
+	msg := getMessage(33).(*ClickEvent)
 	if msg.MessageID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -603,7 +639,7 @@ func DecodeClickEvent(reader io.Reader) (Message, error) {
 
 func DecodeErrorEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &ErrorEvent{}
+	msg := getMessage(34).(*ErrorEvent)
 	if msg.MessageID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -627,7 +663,7 @@ func DecodeErrorEvent(reader io.Reader) (Message, error) {
 
 func DecodeResourceEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &ResourceEvent{}
+	msg := getMessage(35).(*ResourceEvent)
 	if msg.MessageID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -669,7 +705,7 @@ func DecodeResourceEvent(reader io.Reader) (Message, error) {
 
 func DecodeCustomEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &CustomEvent{}
+	msg := getMessage(36).(*CustomEvent)
 	if msg.MessageID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -687,7 +723,7 @@ func DecodeCustomEvent(reader io.Reader) (Message, error) {
 
 func DecodeCSSInsertRule(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &CSSInsertRule{}
+	msg := getMessage(37).(*CSSInsertRule)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -702,7 +738,7 @@ func DecodeCSSInsertRule(reader io.Reader) (Message, error) {
 
 func DecodeCSSDeleteRule(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &CSSDeleteRule{}
+	msg := getMessage(38).(*CSSDeleteRule)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -714,7 +750,7 @@ func DecodeCSSDeleteRule(reader io.Reader) (Message, error) {
 
 func DecodeFetch(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &Fetch{}
+	msg := getMessage(39).(*Fetch)
 	if msg.Method, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -741,7 +777,7 @@ func DecodeFetch(reader io.Reader) (Message, error) {
 
 func DecodeProfiler(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &Profiler{}
+	msg := getMessage(40).(*Profiler)
 	if msg.Name, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -759,7 +795,7 @@ func DecodeProfiler(reader io.Reader) (Message, error) {
 
 func DecodeOTable(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &OTable{}
+	msg := getMessage(41).(*OTable)
 	if msg.Key, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -771,7 +807,7 @@ func DecodeOTable(reader io.Reader) (Message, error) {
 
 func DecodeStateAction(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &StateAction{}
+	msg := getMessage(42).(*StateAction)
 	if msg.Type, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -780,7 +816,7 @@ func DecodeStateAction(reader io.Reader) (Message, error) {
 
 func DecodeStateActionEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &StateActionEvent{}
+	msg := getMessage(43).(*StateActionEvent)
 	if msg.MessageID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -795,7 +831,7 @@ func DecodeStateActionEvent(reader io.Reader) (Message, error) {
 
 func DecodeRedux(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &Redux{}
+	msg := getMessage(44).(*Redux)
 	if msg.Action, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -810,7 +846,7 @@ func DecodeRedux(reader io.Reader) (Message, error) {
 
 func DecodeVuex(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &Vuex{}
+	msg := getMessage(45).(*Vuex)
 	if msg.Mutation, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -822,7 +858,7 @@ func DecodeVuex(reader io.Reader) (Message, error) {
 
 func DecodeMobX(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &MobX{}
+	msg := getMessage(46).(*MobX)
 	if msg.Type, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -834,7 +870,7 @@ func DecodeMobX(reader io.Reader) (Message, error) {
 
 func DecodeNgRx(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &NgRx{}
+	msg := getMessage(47).(*NgRx)
 	if msg.Action, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -849,7 +885,7 @@ func DecodeNgRx(reader io.Reader) (Message, error) {
 
 func DecodeGraphQL(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &GraphQL{}
+	msg := getMessage(48).(*GraphQL)
 	if msg.OperationKind, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -867,7 +903,7 @@ func DecodeGraphQL(reader io.Reader) (Message, error) {
 
 func DecodePerformanceTrack(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &PerformanceTrack{}
+	msg := getMessage(49).(*PerformanceTrack)
 	if msg.Frames, err = ReadInt(reader); err != nil {
 		return nil, err
 	}
@@ -885,7 +921,7 @@ func DecodePerformanceTrack(reader io.Reader) (Message, error) {
 
 func DecodeGraphQLEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &GraphQLEvent{}
+	msg := getMessage(50).(*GraphQLEvent)
 	if msg.MessageID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -909,7 +945,7 @@ func DecodeGraphQLEvent(reader io.Reader) (Message, error) {
 
 func DecodeFetchEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &FetchEvent{}
+	msg := getMessage(51).(*FetchEvent)
 	if msg.MessageID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -939,7 +975,7 @@ func DecodeFetchEvent(reader io.Reader) (Message, error) {
 
 func DecodeDOMDrop(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &DOMDrop{}
+	msg := getMessage(52).(*DOMDrop)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -948,7 +984,7 @@ func DecodeDOMDrop(reader io.Reader) (Message, error) {
 
 func DecodeResourceTiming(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &ResourceTiming{}
+	msg := getMessage(53).(*ResourceTiming)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -978,7 +1014,7 @@ func DecodeResourceTiming(reader io.Reader) (Message, error) {
 
 func DecodeConnectionInformation(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &ConnectionInformation{}
+	msg := getMessage(54).(*ConnectionInformation)
 	if msg.Downlink, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -990,8 +1026,8 @@ func DecodeConnectionInformation(reader io.Reader) (Message, error) {
 
 func DecodeSetPageVisibility(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetPageVisibility{}
-	if msg.hidden, err = ReadBoolean(reader); err != nil {
+	msg := getMessage(55).(*SetPageVisibility)
+	if msg.Hidden, err = ReadBoolean(reader); err != nil {
 		return nil, err
 	}
 	return msg, err
@@ -999,7 +1035,7 @@ func DecodeSetPageVisibility(reader io.Reader) (Message, error) {
 
 func DecodePerformanceTrackAggr(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &PerformanceTrackAggr{}
+	msg := getMessage(56).(*PerformanceTrackAggr)
 	if msg.TimestampStart, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1045,9 +1081,24 @@ func DecodePerformanceTrackAggr(reader io.Reader) (Message, error) {
 	return msg, err
 }
 
+func DecodeSessionAssociation(reader io.Reader) (Message, error) {
+	var err error = nil
+	msg := getMessage(57).(*SessionAssociation)
+	if msg.SessionID, err = ReadUint(reader); err != nil {
+		return nil, err
+	}
+	if msg.PreviousSessionID, err = ReadUint(reader); err != nil {
+		return nil, err
+	}
+	if msg.Timestamp, err = ReadUint(reader); err != nil {
+		return nil, err
+	}
+	return msg, err
+}
+
 func DecodeLongTask(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &LongTask{}
+	msg := getMessage(59).(*LongTask)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1074,7 +1125,7 @@ func DecodeLongTask(reader io.Reader) (Message, error) {
 
 func DecodeSetNodeAttributeURLBased(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetNodeAttributeURLBased{}
+	msg := getMessage(60).(*SetNodeAttributeURLBased)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1092,7 +1143,7 @@ func DecodeSetNodeAttributeURLBased(reader io.Reader) (Message, error) {
 
 func DecodeSetCSSDataURLBased(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &SetCSSDataURLBased{}
+	msg := getMessage(61).(*SetCSSDataURLBased)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1107,7 +1158,7 @@ func DecodeSetCSSDataURLBased(reader io.Reader) (Message, error) {
 
 func DecodeIssueEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IssueEvent{}
+	msg := getMessage(62).(*IssueEvent)
 	if msg.MessageID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1131,7 +1182,7 @@ func DecodeIssueEvent(reader io.Reader) (Message, error) {
 
 func DecodeTechnicalInfo(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &TechnicalInfo{}
+	msg := getMessage(63).(*TechnicalInfo)
 	if msg.Type, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -1143,7 +1194,7 @@ func DecodeTechnicalInfo(reader io.Reader) (Message, error) {
 
 func DecodeCustomIssue(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &CustomIssue{}
+	msg := getMessage(64).(*CustomIssue)
 	if msg.Name, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -1155,7 +1206,7 @@ func DecodeCustomIssue(reader io.Reader) (Message, error) {
 
 func DecodeAssetCache(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &AssetCache{}
+	msg := getMessage(66).(*AssetCache)
 	if msg.URL, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -1164,7 +1215,7 @@ func DecodeAssetCache(reader io.Reader) (Message, error) {
 
 func DecodeCSSInsertRuleURLBased(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &CSSInsertRuleURLBased{}
+	msg := getMessage(67).(*CSSInsertRuleURLBased)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1182,7 +1233,7 @@ func DecodeCSSInsertRuleURLBased(reader io.Reader) (Message, error) {
 
 func DecodeMouseClick(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &MouseClick{}
+	msg := getMessage(69).(*MouseClick)
 	if msg.ID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1200,7 +1251,7 @@ func DecodeMouseClick(reader io.Reader) (Message, error) {
 
 func DecodeCreateIFrameDocument(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &CreateIFrameDocument{}
+	msg := getMessage(70).(*CreateIFrameDocument)
 	if msg.FrameID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1212,7 +1263,7 @@ func DecodeCreateIFrameDocument(reader io.Reader) (Message, error) {
 
 func DecodeAdoptedSSReplaceURLBased(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &AdoptedSSReplaceURLBased{}
+	msg := getMessage(71).(*AdoptedSSReplaceURLBased)
 	if msg.SheetID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1227,7 +1278,7 @@ func DecodeAdoptedSSReplaceURLBased(reader io.Reader) (Message, error) {
 
 func DecodeAdoptedSSReplace(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &AdoptedSSReplace{}
+	msg := getMessage(72).(*AdoptedSSReplace)
 	if msg.SheetID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1239,7 +1290,7 @@ func DecodeAdoptedSSReplace(reader io.Reader) (Message, error) {
 
 func DecodeAdoptedSSInsertRuleURLBased(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &AdoptedSSInsertRuleURLBased{}
+	msg := getMessage(73).(*AdoptedSSInsertRuleURLBased)
 	if msg.SheetID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1257,7 +1308,7 @@ func DecodeAdoptedSSInsertRuleURLBased(reader io.Reader) (Message, error) {
 
 func DecodeAdoptedSSInsertRule(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &AdoptedSSInsertRule{}
+	msg := getMessage(74).(*AdoptedSSInsertRule)
 	if msg.SheetID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1272,7 +1323,7 @@ func DecodeAdoptedSSInsertRule(reader io.Reader) (Message, error) {
 
 func DecodeAdoptedSSDeleteRule(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &AdoptedSSDeleteRule{}
+	msg := getMessage(75).(*AdoptedSSDeleteRule)
 	if msg.SheetID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1284,7 +1335,7 @@ func DecodeAdoptedSSDeleteRule(reader io.Reader) (Message, error) {
 
 func DecodeAdoptedSSAddOwner(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &AdoptedSSAddOwner{}
+	msg := getMessage(76).(*AdoptedSSAddOwner)
 	if msg.SheetID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1296,7 +1347,7 @@ func DecodeAdoptedSSAddOwner(reader io.Reader) (Message, error) {
 
 func DecodeAdoptedSSRemoveOwner(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &AdoptedSSRemoveOwner{}
+	msg := getMessage(77).(*AdoptedSSRemoveOwner)
 	if msg.SheetID, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1308,7 +1359,7 @@ func DecodeAdoptedSSRemoveOwner(reader io.Reader) (Message, error) {
 
 func DecodeZustand(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &Zustand{}
+	msg := getMessage(79).(*Zustand)
 	if msg.Mutation, err = ReadString(reader); err != nil {
 		return nil, err
 	}
@@ -1320,7 +1371,7 @@ func DecodeZustand(reader io.Reader) (Message, error) {
 
 func DecodeIOSBatchMeta(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSBatchMeta{}
+	msg := getMessage(107).(*IOSBatchMeta)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1335,7 +1386,43 @@ func DecodeIOSBatchMeta(reader io.Reader) (Message, error) {
 
 func DecodeIOSSessionStart(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSSessionStart{}
+	msg := getMessage(90).(*IOSSessionStart)
+	if msg.Timestamp, err = ReadUint(reader); err != nil {
+		return nil, err
+	}
+	if msg.ProjectID, err = ReadUint(reader); err != nil {
+		return nil, err
+	}
+	if msg.TrackerVersion, err = ReadString(reader); err != nil {
+		return nil, err
+	}
+	if msg.RevID, err = ReadString(reader); err != nil {
+		return nil, err
+	}
+	if msg.UserUUID, err = ReadString(reader); err != nil {
+		return nil, err
+	}
+	if msg.UserOS, err = ReadString(reader); err != nil {
+		return nil, err
+	}
+	if msg.UserOSVersion, err = ReadString(reader); err != nil {
+		return nil, err
+	}
+	if msg.UserDevice, err = ReadString(reader); err != nil {
+		return nil, err
+	}
+	if msg.UserDeviceType, err = ReadString(reader); err != nil {
+		return nil, err
+	}
+	if msg.UserCountry, err = ReadString(reader); err != nil {
+		return nil, err
+	}
+	return msg, err
+}
+
+func DecodeAndroidSessionStart(reader io.Reader) (Message, error) {
+	var err error = nil
+	msg := getMessage(112).(*AndroidSessionStart)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1371,7 +1458,7 @@ func DecodeIOSSessionStart(reader io.Reader) (Message, error) {
 
 func DecodeIOSSessionEnd(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSSessionEnd{}
+	msg := getMessage(91).(*IOSSessionEnd)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1380,7 +1467,7 @@ func DecodeIOSSessionEnd(reader io.Reader) (Message, error) {
 
 func DecodeIOSMetadata(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSMetadata{}
+	msg := getMessage(92).(*IOSMetadata)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1398,7 +1485,7 @@ func DecodeIOSMetadata(reader io.Reader) (Message, error) {
 
 func DecodeIOSCustomEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSCustomEvent{}
+	msg := getMessage(93).(*IOSCustomEvent)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1416,7 +1503,7 @@ func DecodeIOSCustomEvent(reader io.Reader) (Message, error) {
 
 func DecodeIOSUserID(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSUserID{}
+	msg := getMessage(94).(*IOSUserID)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1431,7 +1518,7 @@ func DecodeIOSUserID(reader io.Reader) (Message, error) {
 
 func DecodeIOSUserAnonymousID(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSUserAnonymousID{}
+	msg := getMessage(95).(*IOSUserAnonymousID)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1446,7 +1533,7 @@ func DecodeIOSUserAnonymousID(reader io.Reader) (Message, error) {
 
 func DecodeIOSScreenChanges(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSScreenChanges{}
+	msg := getMessage(96).(*IOSScreenChanges)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1470,7 +1557,7 @@ func DecodeIOSScreenChanges(reader io.Reader) (Message, error) {
 
 func DecodeIOSCrash(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSCrash{}
+	msg := getMessage(97).(*IOSCrash)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1491,7 +1578,7 @@ func DecodeIOSCrash(reader io.Reader) (Message, error) {
 
 func DecodeIOSScreenEnter(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSScreenEnter{}
+	msg := getMessage(98).(*IOSScreenEnter)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1509,7 +1596,7 @@ func DecodeIOSScreenEnter(reader io.Reader) (Message, error) {
 
 func DecodeIOSScreenLeave(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSScreenLeave{}
+	msg := getMessage(99).(*IOSScreenLeave)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1527,7 +1614,7 @@ func DecodeIOSScreenLeave(reader io.Reader) (Message, error) {
 
 func DecodeIOSClickEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSClickEvent{}
+	msg := getMessage(100).(*IOSClickEvent)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1548,7 +1635,7 @@ func DecodeIOSClickEvent(reader io.Reader) (Message, error) {
 
 func DecodeIOSInputEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSInputEvent{}
+	msg := getMessage(101).(*IOSInputEvent)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1569,7 +1656,7 @@ func DecodeIOSInputEvent(reader io.Reader) (Message, error) {
 
 func DecodeIOSPerformanceEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSPerformanceEvent{}
+	msg := getMessage(102).(*IOSPerformanceEvent)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1587,7 +1674,7 @@ func DecodeIOSPerformanceEvent(reader io.Reader) (Message, error) {
 
 func DecodeIOSLog(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSLog{}
+	msg := getMessage(103).(*IOSLog)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1605,7 +1692,7 @@ func DecodeIOSLog(reader io.Reader) (Message, error) {
 
 func DecodeIOSInternalError(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSInternalError{}
+	msg := getMessage(104).(*IOSInternalError)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1620,7 +1707,7 @@ func DecodeIOSInternalError(reader io.Reader) (Message, error) {
 
 func DecodeIOSNetworkCall(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSNetworkCall{}
+	msg := getMessage(105).(*IOSNetworkCall)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1653,7 +1740,7 @@ func DecodeIOSNetworkCall(reader io.Reader) (Message, error) {
 
 func DecodeIOSPerformanceAggregated(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSPerformanceAggregated{}
+	msg := getMessage(110).(*IOSPerformanceAggregated)
 	if msg.TimestampStart, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1701,7 +1788,7 @@ func DecodeIOSPerformanceAggregated(reader io.Reader) (Message, error) {
 
 func DecodeIOSIssueEvent(reader io.Reader) (Message, error) {
 	var err error = nil
-	msg := &IOSIssueEvent{}
+	msg := getMessage(111).(*IOSIssueEvent)
 	if msg.Timestamp, err = ReadUint(reader); err != nil {
 		return nil, err
 	}
@@ -1738,6 +1825,12 @@ func ReadMessage(t uint64, reader io.Reader) (Message, error) {
 	case 1:
 		return DecodeSessionStart(reader)
 
+	case 83:
+		return DecodeSessionGeo(reader)
+
+	case 84:
+		return DecodeSessionClockSkew(reader)
+
 	case 3:
 		return DecodeSessionEnd(reader)
 
@@ -1900,6 +1993,9 @@ func ReadMessage(t uint64, reader io.Reader) (Message, error) {
 	case 56:
 		return DecodePerformanceTrackAggr(reader)
 
+	case 57:
+		return DecodeSessionAssociation(reader)
+
 	case 59:
 		return DecodeLongTask(reader)
 
@@ -1960,6 +2056,9 @@ func ReadMessage(t uint64, reader io.Reader) (Message, error) {
 	case 90:
 		return DecodeIOSSessionStart(reader)
 
+	case 112:
+		return DecodeAndroidSessionStart(reader)
+
 	case 91:
 		return DecodeIOSSessionEnd(reader)
 
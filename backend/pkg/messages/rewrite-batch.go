@@ -0,0 +1,75 @@
+package messages
+
+import (
+	"bytes"
+	"errors"
+)
+
+// RewriteBatch decodes messages accepted by filter and passes them to rewrite.
+// If rewrite returns a non-nil message, it replaces the original one in the batch keeping its header format.
+// The original data is returned if nothing was replaced, an error is returned if the batch or
+// one of the accepted messages can't be read, so the caller doesn't pass on the data it couldn't check.
+func RewriteBatch(data []byte, filter func(msgType int) bool, rewrite func(msg Message) Message) ([]byte, error) {
+	type replacement struct {
+		start, end int
+		data       []byte
+	}
+	var replacements []replacement
+
+	iter := NewIterator(data).(*iteratorImpl)
+	for iter.Next() {
+		if !filter(iter.Type()) {
+			continue
+		}
+		msg := iter.Message().Decode()
+		if msg == nil {
+			return nil, &IteratorError{Type: iter.msgType, Offset: iter.offset, Index: iter.index, Err: errors.New("can't decode message")}
+		}
+		newMsg := rewrite(msg)
+		if newMsg == nil {
+			continue
+		}
+		// Header is copied from the original message, only the size of the body is updated
+		body, err := messageBody(newMsg.Encode())
+		if err != nil {
+			return nil, err
+		}
+		encoded := make([]byte, 0, int(iter.bodyStart-iter.offset)+len(body))
+		encoded = append(encoded, data[iter.offset:iter.bodyStart]...)
+		if iter.version > 0 && messageHasSize(iter.msgType) {
+			if uint64(len(body)) > maxSize {
+				return nil, &SizeError{Err: ErrMessageTooLarge, Size: uint64(len(body)), Limit: maxSize}
+			}
+			WriteSize(uint64(len(body)), encoded, len(encoded)-sizeBytes)
+		}
+		replacements = append(replacements, replacement{
+			start: int(iter.offset),
+			end:   len(data) - iter.data.Len(),
+			data:  append(encoded, body...),
+		})
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	if len(replacements) == 0 {
+		return data, nil
+	}
+
+	res := make([]byte, 0, len(data))
+	prev := 0
+	for _, r := range replacements {
+		res = append(res, data[prev:r.start]...)
+		res = append(res, r.data...)
+		prev = r.end
+	}
+	return append(res, data[prev:]...), nil
+}
+
+// messageBody strips the type from the encoded message
+func messageBody(encoded []byte) ([]byte, error) {
+	reader := bytes.NewReader(encoded)
+	if _, err := ReadUint(reader); err != nil {
+		return nil, err
+	}
+	return encoded[len(encoded)-reader.Len():], nil
+}
@@ -0,0 +1,55 @@
+package messages
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Batch rejection reasons
+const (
+	BatchErrEmpty   = "empty"
+	BatchErrDecode  = "decode"
+	BatchErrVersion = "version"
+)
+
+// BatchError describes the first message in a batch that can't be read by consumers
+type BatchError struct {
+	Reason string
+	Index  uint64 // Position of the broken message in the batch
+	Err    error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%s error on message %d: %s", e.Reason, e.Index, e.Err)
+}
+
+// ValidateBatch walks through the whole batch and decodes every message with the given limits,
+// so a batch which is accepted can be read by consumers completely.
+func ValidateBatch(data []byte, opts DecoderOptions) *BatchError {
+	if len(data) == 0 {
+		return &BatchError{Reason: BatchErrEmpty, Err: errors.New("batch is empty")}
+	}
+	iter := NewIteratorWithOptions(data, opts).(*iteratorImpl)
+	var index uint64
+	for iter.Next() {
+		msg := iter.Message().Decode()
+		if msg == nil {
+			return &BatchError{Reason: BatchErrDecode, Index: index,
+				Err: fmt.Errorf("can't decode message, type: %d", iter.msgType)}
+		}
+		ReleaseMessage(msg)
+		index++
+	}
+	switch {
+	case iter.version > 1:
+		return &BatchError{Reason: BatchErrVersion, Index: index,
+			Err: fmt.Errorf("unsupported batch version: %d", iter.version)}
+	case iter.err != nil:
+		return &BatchError{Reason: BatchErrDecode, Index: index, Err: iter.err}
+	case iter.skipped > 0:
+		// Consumers would skip them, but there is no reason to accept broken messages
+		return &BatchError{Reason: BatchErrDecode, Index: index,
+			Err: fmt.Errorf("batch has %d broken messages", iter.skipped)}
+	}
+	return nil
+}
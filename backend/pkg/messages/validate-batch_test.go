@@ -0,0 +1,52 @@
+package messages
+
+import (
+	"strings"
+	"testing"
+)
+
+// sizedMessage returns the message of batch version 1 with the given body
+func sizedMessage(tp byte, body []byte) []byte {
+	msg := make([]byte, 1+sizeBytes, 1+sizeBytes+len(body))
+	msg[0] = tp
+	WriteSize(uint64(len(body)), msg, 1)
+	return append(msg, body...)
+}
+
+func TestValidateBatch(t *testing.T) {
+	meta := Encode(&BatchMetadata{Version: 1, PageNo: 1, FirstIndex: 1, Timestamp: 1600000000000})
+	valid := append(append([]byte{}, meta...), EncodeSized(&SetNodeAttribute{ID: 1, Name: "class", Value: "a"})...)
+	large := append(append([]byte{}, meta...), EncodeSized(&SetNodeAttribute{ID: 1, Name: "class", Value: strings.Repeat("a", 2000)})...)
+	opts := DecoderOptions{MaxFieldSize: 1000, MaxMessageSize: 10000}
+	tests := []struct {
+		name   string
+		batch  []byte
+		reason string
+		index  uint64
+	}{
+		{"valid", valid, "", 0},
+		{"empty", nil, BatchErrEmpty, 0},
+		{"unsupported version", Encode(&BatchMetadata{Version: 2}), BatchErrVersion, 0},
+		// Framing is fine, but the name of the attribute is missing
+		{"broken body", append(append([]byte{}, valid...), sizedMessage(MsgSetNodeAttribute, []byte{1})...), BatchErrDecode, 2},
+		{"field over the limit", large, BatchErrDecode, 1},
+		{"truncated", valid[:len(valid)-1], BatchErrDecode, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBatch(tt.batch, opts)
+			if tt.reason == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("batch isn't rejected")
+			}
+			if err.Reason != tt.reason || err.Index != tt.index {
+				t.Errorf("got %s on message %d, want %s on message %d", err.Reason, err.Index, tt.reason, tt.index)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package monitoring
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
 	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
@@ -22,6 +24,7 @@ type Metrics struct {
 	counters       map[string]syncfloat64.Counter
 	upDownCounters map[string]syncfloat64.UpDownCounter
 	histograms     map[string]syncfloat64.Histogram
+	gauges         map[string]struct{}
 }
 
 func New(name string) *Metrics {
@@ -29,6 +32,7 @@ func New(name string) *Metrics {
 		counters:       make(map[string]syncfloat64.Counter),
 		upDownCounters: make(map[string]syncfloat64.UpDownCounter),
 		histograms:     make(map[string]syncfloat64.Histogram),
+		gauges:         make(map[string]struct{}),
 	}
 	m.initPrometheusDataExporter()
 	m.initMetrics(name)
@@ -136,3 +140,27 @@ func (m *Metrics) RegisterHistogram(name string) (syncfloat64.Histogram, error)
 func (m *Metrics) GetHistogram(name string) syncfloat64.Histogram {
 	return m.histograms[name]
 }
+
+/*
+Gauge is an asynchronous instrument which reports the current value on every collection, for example:
+- queue length
+- age of the oldest item
+*/
+
+func (m *Metrics) RegisterGauge(name string, observe func() float64) error {
+	if _, ok := m.gauges[name]; ok {
+		return fmt.Errorf("gauge %s already exists", name)
+	}
+	gauge, err := m.meter.AsyncFloat64().Gauge(name)
+	if err != nil {
+		return fmt.Errorf("failed to initialize gauge: %v", err)
+	}
+	err = m.meter.RegisterCallback([]instrument.Asynchronous{gauge}, func(ctx context.Context) {
+		gauge.Observe(ctx, observe())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register gauge callback: %v", err)
+	}
+	m.gauges[name] = struct{}{}
+	return nil
+}
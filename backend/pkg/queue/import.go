@@ -12,3 +12,9 @@ func NewConsumer(group string, topics []string, handler types.MessageHandler, _
 func NewProducer(_ int, _ bool) types.Producer {
 	return redisstream.NewProducer()
 }
+
+// NewReportingProducer returns a producer which reports undelivered messages,
+// redis producer returns all errors from Produce, so onFailed is never called
+func NewReportingProducer(_ int, _ types.DeliveryFailureHandler) types.Producer {
+	return redisstream.NewProducer()
+}
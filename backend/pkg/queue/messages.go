@@ -1,12 +1,63 @@
 package queue
 
 import (
+	"context"
+	"log"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+
 	"openreplay/backend/pkg/messages"
+	"openreplay/backend/pkg/monitoring"
 	"openreplay/backend/pkg/queue/types"
 )
 
-func NewMessageConsumer(group string, topics []string, handler types.RawMessageHandler, autoCommit bool, messageSizeLimit int) types.Consumer {
+func NewMessageConsumer(group string, topics []string, handler types.RawMessageHandler, autoCommit bool, messageSizeLimit int, metrics *monitoring.Metrics) types.Consumer {
+	skippedBatches := queueCounter(metrics, "batches_skipped")
+	abortedBatches := queueCounter(metrics, "batches_aborted")
+	// Batch can't be larger than the queue message, so neither can its messages and fields
+	decoderOptions := messages.NewDecoderOptions(messageSizeLimit)
+	// Batches over the size limit come in chunks, handler gets them after all the parts are received
+	partitioned := newAssembler(messageSizeLimit, metrics)
+	partitionedOptions := messages.NewDecoderOptions(maxPartsPerBatch * messageSizeLimit)
 	return NewConsumer(group, topics, func(sessionID uint64, value []byte, meta *types.Meta) {
-		handler(sessionID, messages.NewIterator(value), meta)
+		opts := decoderOptions
+		if messages.IsPartitioned(value) {
+			if value = partitioned.add(sessionID, value, meta); value == nil {
+				return
+			}
+			opts = partitionedOptions
+		}
+		iter := messages.NewIteratorWithOptions(value, opts)
+		handler(sessionID, iter, meta)
+
+		topic := []attribute.KeyValue{attribute.String("topic", meta.Topic)}
+		if skipped := iter.Skipped(); skipped > 0 {
+			log.Printf("%d broken messages were skipped, topic: %s, sessID: %d", skipped, meta.Topic, sessionID)
+			if skippedBatches != nil {
+				skippedBatches.Add(context.Background(), 1, topic...)
+			}
+		}
+		if err := iter.Err(); err != nil {
+			log.Printf("batch aborted, topic: %s, sessID: %d, err: %s", meta.Topic, sessionID, err)
+			if abortedBatches != nil {
+				abortedBatches.Add(context.Background(), 1, topic...)
+			}
+		}
 	}, autoCommit, messageSizeLimit)
 }
+
+// queueCounter returns the counter of the service, it's shared if the service has several consumers
+func queueCounter(metrics *monitoring.Metrics, name string) syncfloat64.Counter {
+	if metrics == nil {
+		return nil
+	}
+	if counter := metrics.GetCounter(name); counter != nil {
+		return counter
+	}
+	counter, err := metrics.RegisterCounter(name)
+	if err != nil {
+		log.Printf("can't create %s metric: %s", name, err)
+	}
+	return counter
+}
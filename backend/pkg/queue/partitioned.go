@@ -0,0 +1,193 @@
+package queue
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+
+	"openreplay/backend/pkg/messages"
+	"openreplay/backend/pkg/monitoring"
+	"openreplay/backend/pkg/queue/types"
+)
+
+const (
+	// Space left in the queue message for the key and the broker's overhead
+	chunkOverhead = 1024
+	// Batch is restored from at most this number of chunks, the size of beacons is limited by http anyway
+	maxPartsPerBatch = 64
+	// Incomplete batch is dropped if there are no new chunks for it during this time
+	partitionedTimeout = 2 * time.Minute
+	// Total size of incomplete batches in messages of the size limit
+	partitionedMemoryLimit = 128
+)
+
+// partitionedProducer splits batches larger than the queue message size limit into PartitionedMessage chunks
+type partitionedProducer struct {
+	types.Producer
+	chunkSize int
+}
+
+// NewPartitionedProducer wraps the producer, so it can send batches over the message size limit
+func NewPartitionedProducer(producer types.Producer, messageSizeLimit int) types.Producer {
+	return &partitionedProducer{
+		Producer:  producer,
+		chunkSize: messageSizeLimit - chunkOverhead,
+	}
+}
+
+func (p *partitionedProducer) Produce(topic string, key uint64, value []byte) error {
+	if len(value) <= p.chunkSize {
+		return p.Producer.Produce(topic, key, value)
+	}
+	chunks, err := messages.SplitBatch(value, p.chunkSize)
+	if err != nil {
+		return err
+	}
+	// Chunks have the same key, so they get to the same partition in order
+	for _, chunk := range chunks {
+		if err := p.Producer.Produce(topic, key, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *partitionedProducer) ProduceToPartition(topic string, partition, key uint64, value []byte) error {
+	if len(value) <= p.chunkSize {
+		return p.Producer.ProduceToPartition(topic, partition, key, value)
+	}
+	chunks, err := messages.SplitBatch(value, p.chunkSize)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if err := p.Producer.ProduceToPartition(topic, partition, key, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type partitionedKey struct {
+	topic     string
+	sessionID uint64
+}
+
+// partitionedBatch keeps received parts of the batch
+type partitionedBatch struct {
+	parts    [][]byte
+	received int
+	size     int
+	updated  time.Time
+}
+
+// assembler restores batches from PartitionedMessage chunks. Handler of the consumer is called
+// from one goroutine, so there is no locking.
+// Chunks don't have a batch identifier: chunks of a batch are produced together by one request and
+// the tracker sends batches of the session one by one, so they come to the same partition in a row.
+// A part which is already received or a different number of parts means the next batch has started.
+// Chunks are committed as usual messages, so parts of incomplete batches are lost if the service restarts.
+type assembler struct {
+	batches     map[partitionedKey]*partitionedBatch
+	size        int
+	memoryLimit int
+	lastCleanup time.Time
+	dropped     syncfloat64.Counter
+}
+
+func newAssembler(messageSizeLimit int, metrics *monitoring.Metrics) *assembler {
+	dropped := queueCounter(metrics, "batches_partitioned_dropped")
+	return &assembler{
+		batches:     make(map[partitionedKey]*partitionedBatch),
+		memoryLimit: partitionedMemoryLimit * messageSizeLimit,
+		lastCleanup: time.Now(),
+		dropped:     dropped,
+	}
+}
+
+// add stores the chunk and returns the whole batch when all its parts are received
+func (a *assembler) add(sessionID uint64, chunk []byte, meta *types.Meta) []byte {
+	now := time.Now()
+	if now.Sub(a.lastCleanup) > partitionedTimeout/2 {
+		a.removeExpired(now)
+	}
+	header, part, err := messages.ReadPartition(chunk)
+	if err != nil || header.PartTotal > maxPartsPerBatch {
+		log.Printf("wrong partitioned message, topic: %s, sessID: %d, err: %v", meta.Topic, sessionID, err)
+		a.countDropped(meta.Topic, "wrong")
+		return nil
+	}
+
+	key := partitionedKey{topic: meta.Topic, sessionID: sessionID}
+	batch, ok := a.batches[key]
+	if ok && (len(batch.parts) != int(header.PartTotal) || batch.parts[header.PartNo] != nil) {
+		// The batch is re-sent (e.g. from the spool of http) or the rest of the previous one is lost
+		a.remove(key, "restarted")
+		ok = false
+	}
+	if !ok {
+		batch = &partitionedBatch{parts: make([][]byte, header.PartTotal)}
+		a.batches[key] = batch
+	}
+	batch.parts[header.PartNo] = append([]byte(nil), part...)
+	batch.received++
+	batch.size += len(part)
+	a.size += len(part)
+	batch.updated = now
+	if batch.received < len(batch.parts) {
+		a.freeMemory()
+		return nil
+	}
+
+	data := make([]byte, 0, batch.size)
+	for _, p := range batch.parts {
+		data = append(data, p...)
+	}
+	a.size -= batch.size
+	delete(a.batches, key)
+	return data
+}
+
+func (a *assembler) remove(key partitionedKey, reason string) {
+	a.size -= a.batches[key].size
+	delete(a.batches, key)
+	log.Printf("incomplete partitioned batch dropped, topic: %s, sessID: %d, reason: %s", key.topic, key.sessionID, reason)
+	a.countDropped(key.topic, reason)
+}
+
+func (a *assembler) removeExpired(now time.Time) {
+	a.lastCleanup = now
+	for key, batch := range a.batches {
+		if now.Sub(batch.updated) > partitionedTimeout {
+			a.remove(key, "timeout")
+		}
+	}
+}
+
+// freeMemory drops the least recently updated batches while the memory limit is exceeded
+func (a *assembler) freeMemory() {
+	for a.size > a.memoryLimit {
+		var (
+			oldestKey partitionedKey
+			oldest    *partitionedBatch
+		)
+		for key, batch := range a.batches {
+			if oldest == nil || batch.updated.Before(oldest.updated) {
+				oldestKey, oldest = key, batch
+			}
+		}
+		if oldest == nil {
+			return
+		}
+		a.remove(oldestKey, "memory")
+	}
+}
+
+func (a *assembler) countDropped(topic, reason string) {
+	if a.dropped != nil {
+		a.dropped.Add(context.Background(), 1, attribute.String("topic", topic), attribute.String("reason", reason))
+	}
+}
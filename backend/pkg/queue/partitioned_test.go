@@ -0,0 +1,164 @@
+package queue
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"openreplay/backend/pkg/messages"
+	"openreplay/backend/pkg/queue/types"
+)
+
+const testMessageSize = 1000
+
+func testChunks(t *testing.T, size int) ([]byte, [][]byte) {
+	t.Helper()
+	batch := make([]byte, size)
+	for i := range batch {
+		batch[i] = byte(i % 251)
+	}
+	chunks, err := messages.SplitBatch(batch, testMessageSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return batch, chunks
+}
+
+func addAll(a *assembler, sessionID uint64, chunks [][]byte, order []int) []byte {
+	meta := &types.Meta{Topic: "raw"}
+	var restored []byte
+	for _, i := range order {
+		if data := a.add(sessionID, chunks[i], meta); data != nil {
+			restored = data
+		}
+	}
+	return restored
+}
+
+func TestAssemblerOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		order []int
+	}{
+		{"in order", []int{0, 1, 2, 3}},
+		{"reversed", []int{3, 2, 1, 0}},
+		{"shuffled", []int{2, 0, 3, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch, chunks := testChunks(t, 3500)
+			a := newAssembler(testMessageSize, nil)
+			if restored := addAll(a, 1, chunks, tt.order); !bytes.Equal(restored, batch) {
+				t.Error("batch isn't restored")
+			}
+			if len(a.batches) != 0 || a.size != 0 {
+				t.Errorf("assembler isn't empty: %d batches, %d bytes", len(a.batches), a.size)
+			}
+		})
+	}
+}
+
+func TestAssemblerSessions(t *testing.T) {
+	batch1, chunks1 := testChunks(t, 2500)
+	batch2, chunks2 := testChunks(t, 1500)
+	a := newAssembler(testMessageSize, nil)
+	meta := &types.Meta{Topic: "raw"}
+	a.add(1, chunks1[0], meta)
+	a.add(2, chunks2[0], meta)
+	a.add(1, chunks1[1], meta)
+	if restored := a.add(2, chunks2[1], meta); !bytes.Equal(restored, batch2) {
+		t.Error("batch of session 2 isn't restored")
+	}
+	if restored := a.add(1, chunks1[2], meta); !bytes.Equal(restored, batch1) {
+		t.Error("batch of session 1 isn't restored")
+	}
+}
+
+func TestAssemblerRestart(t *testing.T) {
+	batch, chunks := testChunks(t, 3500)
+	a := newAssembler(testMessageSize, nil)
+	// Part 2 is lost, then the whole batch is re-sent
+	if restored := addAll(a, 1, chunks, []int{0, 1, 3, 0, 1, 2, 3}); !bytes.Equal(restored, batch) {
+		t.Error("re-sent batch isn't restored")
+	}
+	if len(a.batches) != 0 {
+		t.Errorf("%d incomplete batches are left", len(a.batches))
+	}
+
+	// The rest of the previous batch is lost, the next one has a different number of parts
+	next, nextChunks := testChunks(t, 1500)
+	a.add(1, chunks[0], &types.Meta{Topic: "raw"})
+	if restored := addAll(a, 1, nextChunks, []int{0, 1}); !bytes.Equal(restored, next) {
+		t.Error("next batch isn't restored")
+	}
+}
+
+func TestAssemblerTimeout(t *testing.T) {
+	_, chunks := testChunks(t, 3500)
+	a := newAssembler(testMessageSize, nil)
+	addAll(a, 1, chunks, []int{0, 1, 3})
+	if len(a.batches) != 1 {
+		t.Fatalf("got %d incomplete batches, want 1", len(a.batches))
+	}
+
+	// Cleanup doesn't drop batches updated recently
+	a.removeExpired(time.Now())
+	if len(a.batches) != 1 {
+		t.Fatal("batch is dropped before the timeout")
+	}
+
+	// The missing part never comes
+	past := time.Now().Add(-partitionedTimeout - time.Second)
+	a.batches[partitionedKey{topic: "raw", sessionID: 1}].updated = past
+	a.lastCleanup = past
+	_, other := testChunks(t, 1500)
+	a.add(2, other[0], &types.Meta{Topic: "raw"})
+	if _, ok := a.batches[partitionedKey{topic: "raw", sessionID: 1}]; ok {
+		t.Error("expired batch isn't dropped")
+	}
+	if _, part, _ := messages.ReadPartition(other[0]); a.size != len(part) {
+		t.Errorf("size of the assembler is %d, want %d", a.size, len(part))
+	}
+}
+
+func TestAssemblerTooManyParts(t *testing.T) {
+	a := newAssembler(testMessageSize, nil)
+	meta := &types.Meta{Topic: "raw"}
+	chunk := append(messages.Encode(&messages.PartitionedMessage{PartNo: 0, PartTotal: maxPartsPerBatch + 1}), 1, 2, 3)
+	if a.add(1, chunk, meta) != nil || len(a.batches) != 0 {
+		t.Error("chunk of the batch with too many parts is accepted")
+	}
+	chunk = append(messages.Encode(&messages.PartitionedMessage{PartNo: 0, PartTotal: maxPartsPerBatch}), 1, 2, 3)
+	if a.add(1, chunk, meta); len(a.batches) != 1 {
+		t.Error("chunk of the batch with the maximum number of parts is dropped")
+	}
+}
+
+func TestAssemblerMemoryLimit(t *testing.T) {
+	_, chunks := testChunks(t, 3500)
+	a := newAssembler(testMessageSize, nil)
+	meta := &types.Meta{Topic: "raw"}
+	_, part, _ := messages.ReadPartition(chunks[0])
+	sessions := partitionedMemoryLimit*testMessageSize/len(part) + 10
+	base := time.Now().Add(-time.Hour)
+	for sessionID := 1; sessionID <= sessions; sessionID++ {
+		a.add(uint64(sessionID), chunks[0], meta)
+		if a.size > a.memoryLimit {
+			t.Fatalf("size %d is over the limit %d", a.size, a.memoryLimit)
+		}
+		// Distinct update times, so the order of dropping is known
+		if batch, ok := a.batches[partitionedKey{topic: "raw", sessionID: uint64(sessionID)}]; ok {
+			batch.updated = base.Add(time.Duration(sessionID) * time.Second)
+		}
+	}
+	if len(a.batches) >= sessions {
+		t.Fatal("no batches were dropped")
+	}
+	// The least recently updated batches are dropped
+	if _, ok := a.batches[partitionedKey{topic: "raw", sessionID: 1}]; ok {
+		t.Error("the oldest batch isn't dropped")
+	}
+	if _, ok := a.batches[partitionedKey{topic: "raw", sessionID: uint64(sessions)}]; !ok {
+		t.Error("the newest batch is dropped")
+	}
+}
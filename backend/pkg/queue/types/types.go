@@ -25,6 +25,9 @@ type Meta struct {
 	Timestamp int64
 }
 
+// DeliveryFailureHandler gets the message which was accepted by Produce, but certainly wasn't delivered
+type DeliveryFailureHandler func(topic string, key uint64, value []byte)
+
 type MessageHandler func(uint64, []byte, *Meta)
 type DecodedMessageHandler func(uint64, messages.Message, *Meta)
 type RawMessageHandler func(uint64, messages.Iterator, *Meta)
@@ -22,3 +22,16 @@ func getRedisClient() *redis.Client {
 	}
 	return redisClient
 }
+
+// Ping checks connection to redis
+func Ping() error {
+	return getRedisClient().Ping().Err()
+}
+
+// GetClient returns shared redis client or nil if redis isn't configured for the service
+func GetClient() *redis.Client {
+	if env.StringOptional("REDIS_STRING") == "" {
+		return nil
+	}
+	return getRedisClient()
+}
@@ -0,0 +1,453 @@
+package rrweb
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	. "openreplay/backend/pkg/messages"
+)
+
+// IDs of nodes which don't exist in rrweb recording (e.g. text of inlined stylesheets)
+const syntheticIDBase = 1 << 40
+
+const maxLabelLength = 100
+
+type node struct {
+	id       int64
+	orID     uint64
+	parent   *node
+	children []*node
+	emitted  bool // Node exists in OpenReplay DOM
+	tag      string
+	text     string
+	attrs    map[string]string
+}
+
+type converter struct {
+	nodes       map[int64]*node
+	docID       int64
+	htmlID      int64
+	msgs        []Message
+	timestamp   int64
+	syntheticID uint64
+	inputs      map[uint64]bool // Inputs with sent SetInputTarget
+}
+
+// Convert translates rrweb events into OpenReplay messages, every change of time is marked by Timestamp message
+func Convert(events []*Event) ([]Message, error) {
+	sorted := make([]*Event, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Timestamp < sorted[j].Timestamp })
+
+	c := &converter{
+		nodes:       make(map[int64]*node),
+		docID:       -1,
+		htmlID:      -1,
+		syntheticID: syntheticIDBase,
+		inputs:      make(map[uint64]bool),
+	}
+	hasSnapshot := false
+	for i, ev := range sorted {
+		c.at(ev.Timestamp)
+		var err error
+		switch ev.Type {
+		case EventFullSnapshot:
+			err = c.fullSnapshot(ev.Data)
+			hasSnapshot = hasSnapshot || err == nil
+		case EventIncrementalSnapshot:
+			err = c.incrementalSnapshot(ev.Timestamp, ev.Data)
+		case EventMeta:
+			err = c.meta(ev.Timestamp, ev.Data)
+		case EventCustom:
+			err = c.custom(ev.Data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't convert event %d of type %d: %s", i, ev.Type, err)
+		}
+	}
+	if !hasSnapshot {
+		return nil, fmt.Errorf("recording doesn't have a full snapshot")
+	}
+	return c.msgs, nil
+}
+
+func (c *converter) add(msg Message) {
+	c.msgs = append(c.msgs, msg)
+}
+
+// at switches current time, it never goes back to keep messages ordered
+func (c *converter) at(ts int64) {
+	if ts <= c.timestamp {
+		return
+	}
+	c.timestamp = ts
+	c.add(&Timestamp{Timestamp: uint64(ts)})
+}
+
+func (c *converter) meta(ts int64, data json.RawMessage) error {
+	m := &metaData{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return err
+	}
+	c.add(&SetPageLocation{URL: m.Href, NavigationStart: uint64(ts)})
+	c.add(&SetViewportSize{Width: m.Width, Height: m.Height})
+	return nil
+}
+
+func (c *converter) custom(data json.RawMessage) error {
+	m := &customData{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return err
+	}
+	c.add(&RawCustomEvent{Name: m.Tag, Payload: string(m.Payload)})
+	return nil
+}
+
+func (c *converter) fullSnapshot(data json.RawMessage) error {
+	m := &fullSnapshotData{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return err
+	}
+	if m.Node == nil || m.Node.Type != NodeDocument {
+		return fmt.Errorf("snapshot doesn't start with document")
+	}
+	c.nodes = make(map[int64]*node)
+	c.inputs = make(map[uint64]bool)
+	c.htmlID = -1
+	c.docID = m.Node.ID
+	doc := &node{id: m.Node.ID}
+	c.nodes[doc.id] = doc
+	c.add(&CreateDocument{})
+	for _, child := range m.Node.ChildNodes {
+		c.insert(child, doc, nil)
+	}
+	c.add(&SetViewportScroll{X: m.InitialOffset.Left, Y: m.InitialOffset.Top})
+	return nil
+}
+
+// insert adds serialized node with its children before the next one (or to the end if next is nil)
+func (c *converter) insert(sn *serializedNode, parent *node, next *node) {
+	if sn == nil || sn.ID < 0 {
+		return
+	}
+	if old, ok := c.nodes[sn.ID]; ok { // Node is moved, rrweb sends it again
+		c.remove(old)
+	}
+	n := &node{id: sn.ID, orID: uint64(sn.ID), parent: parent, tag: strings.ToLower(sn.TagName), attrs: make(map[string]string)}
+	c.nodes[sn.ID] = n
+
+	pos := len(parent.children)
+	for i, ch := range parent.children {
+		if ch == next {
+			pos = i
+			break
+		}
+	}
+	index := 0
+	for _, ch := range parent.children[:pos] {
+		if ch.emitted {
+			index++
+		}
+	}
+	parent.children = append(parent.children[:pos], append([]*node{n}, parent.children[pos:]...)...)
+
+	switch sn.Type {
+	case NodeElement:
+		if parent.id == c.docID && c.htmlID < 0 {
+			// The root element exists in OpenReplay DOM after CreateDocument with ID 0
+			c.htmlID, n.orID, n.emitted = sn.ID, 0, true
+		} else if parent.emitted {
+			tag := sn.TagName
+			cssText, inlined := sn.Attributes["_cssText"].(string)
+			if inlined && n.tag == "link" {
+				tag, n.tag = "style", "style"
+			}
+			c.add(&CreateElementNode{ID: n.orID, ParentID: parent.orID, Index: uint64(index), Tag: tag, SVG: sn.IsSVG})
+			n.emitted = true
+			if inlined && n.tag == "style" {
+				text := &node{id: -1, orID: c.nextSyntheticID(), parent: n, emitted: true, text: cssText}
+				n.children = append(n.children, text)
+				c.add(&CreateTextNode{ID: text.orID, ParentID: n.orID, Index: 0})
+				c.add(&SetCSSData{ID: text.orID, Data: cssText})
+			}
+		}
+		if n.emitted {
+			for name, value := range sn.Attributes {
+				c.setAttribute(n, name, value)
+			}
+		}
+		for _, child := range sn.ChildNodes {
+			c.insert(child, n, nil)
+		}
+	case NodeText:
+		n.text = sn.TextContent
+		if parent.emitted && parent.id != c.docID {
+			c.add(&CreateTextNode{ID: n.orID, ParentID: parent.orID, Index: uint64(index)})
+			n.emitted = true
+			c.setText(n)
+		}
+	}
+}
+
+func (c *converter) nextSyntheticID() uint64 {
+	c.syntheticID++
+	return c.syntheticID
+}
+
+// remove deletes node with its children from the tree
+func (c *converter) remove(n *node) {
+	if n.parent != nil {
+		for i, ch := range n.parent.children {
+			if ch == n {
+				n.parent.children = append(n.parent.children[:i], n.parent.children[i+1:]...)
+				break
+			}
+		}
+	}
+	if n.emitted && n.orID != 0 {
+		c.add(&RemoveNode{ID: n.orID})
+	}
+	c.forget(n)
+}
+
+func (c *converter) forget(n *node) {
+	if n.id >= 0 && c.nodes[n.id] == n {
+		delete(c.nodes, n.id)
+	}
+	for _, ch := range n.children {
+		c.forget(ch)
+	}
+}
+
+func (c *converter) setText(n *node) {
+	if n.parent != nil && n.parent.tag == "style" {
+		c.add(&SetCSSData{ID: n.orID, Data: n.text})
+		return
+	}
+	c.add(&SetNodeData{ID: n.orID, Data: n.text})
+}
+
+func (c *converter) setAttribute(n *node, name string, value interface{}) {
+	switch name {
+	case "_cssText", "rr_width", "rr_height", "rr_mediaState", "rr_mediaCurrentTime", "rr_dataURL":
+		return
+	case "rr_scrollLeft", "rr_scrollTop":
+		x, _ := strconv.ParseFloat(n.attrs["rr_scrollLeft"], 64)
+		y, _ := strconv.ParseFloat(n.attrs["rr_scrollTop"], 64)
+		if v, ok := value.(float64); ok {
+			if name == "rr_scrollLeft" {
+				x = v
+			} else {
+				y = v
+			}
+			n.attrs[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		c.add(&SetNodeScroll{ID: n.orID, X: int64(x), Y: int64(y)})
+		return
+	}
+	switch v := value.(type) {
+	case nil:
+		delete(n.attrs, name)
+		c.add(&RemoveNodeAttribute{ID: n.orID, Name: name})
+		return
+	case string:
+		n.attrs[name] = v
+	case bool:
+		if !v {
+			delete(n.attrs, name)
+			c.add(&RemoveNodeAttribute{ID: n.orID, Name: name})
+			return
+		}
+		n.attrs[name] = ""
+	case float64:
+		n.attrs[name] = strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}: // Diff of style properties
+		n.attrs[name] = mergeStyle(n.attrs[name], v)
+	default:
+		return
+	}
+	c.add(&SetNodeAttribute{ID: n.orID, Name: name, Value: n.attrs[name]})
+}
+
+// mergeStyle applies rrweb style diff, where value is a string, [value, priority] or false for removed property
+func mergeStyle(style string, diff map[string]interface{}) string {
+	var props []string
+	values := make(map[string]string)
+	for _, decl := range strings.Split(style, ";") {
+		kv := strings.SplitN(decl, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		prop := strings.TrimSpace(kv[0])
+		if _, ok := values[prop]; !ok {
+			props = append(props, prop)
+		}
+		values[prop] = strings.TrimSpace(kv[1])
+	}
+	for prop, value := range diff {
+		if _, ok := values[prop]; !ok {
+			props = append(props, prop)
+		}
+		switch v := value.(type) {
+		case string:
+			values[prop] = v
+		case []interface{}:
+			if len(v) == 2 {
+				values[prop] = fmt.Sprintf("%v !%v", v[0], v[1])
+			} else if len(v) == 1 {
+				values[prop] = fmt.Sprintf("%v", v[0])
+			}
+		default:
+			delete(values, prop)
+		}
+	}
+	var res []string
+	for _, prop := range props {
+		if value, ok := values[prop]; ok {
+			res = append(res, prop+": "+value)
+		}
+	}
+	return strings.Join(res, "; ")
+}
+
+func (c *converter) incrementalSnapshot(ts int64, data json.RawMessage) error {
+	m := &incrementalData{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return err
+	}
+	switch m.Source {
+	case SourceMutation:
+		c.mutation(m)
+	case SourceMouseMove, SourceTouchMove, SourceDrag:
+		for _, p := range m.Positions {
+			c.at(ts + p.TimeOffset)
+			c.add(&MouseMove{X: coordinate(p.X), Y: coordinate(p.Y)})
+		}
+	case SourceMouseInteraction:
+		n, ok := c.nodes[m.ID]
+		if m.Type != mouseClick || !ok || !n.emitted {
+			return nil
+		}
+		c.add(&MouseMove{X: coordinate(m.X), Y: coordinate(m.Y)})
+		c.add(&MouseClick{ID: n.orID, Label: label(n)})
+	case SourceScroll:
+		n, ok := c.nodes[m.ID]
+		if m.ID == c.docID || m.ID == c.htmlID {
+			c.add(&SetViewportScroll{X: int64(m.X), Y: int64(m.Y)})
+		} else if ok && n.emitted {
+			c.add(&SetNodeScroll{ID: n.orID, X: int64(m.X), Y: int64(m.Y)})
+		}
+	case SourceViewportResize:
+		c.add(&SetViewportSize{Width: m.Width, Height: m.Height})
+	case SourceInput:
+		n, ok := c.nodes[m.ID]
+		if !ok || !n.emitted {
+			return nil
+		}
+		if !c.inputs[n.orID] {
+			c.inputs[n.orID] = true
+			c.add(&SetInputTarget{ID: n.orID, Label: inputLabel(n)})
+		}
+		if t := n.attrs["type"]; t == "checkbox" || t == "radio" {
+			c.add(&SetInputChecked{ID: n.orID, Checked: m.IsChecked})
+		} else {
+			c.add(&SetInputValue{ID: n.orID, Value: m.Text})
+		}
+	}
+	return nil
+}
+
+// mutation applies changes in the same order as rrweb replayer: removes, adds, texts and attributes
+func (c *converter) mutation(m *incrementalData) {
+	for _, r := range m.Removes {
+		if n, ok := c.nodes[r.ID]; ok {
+			c.remove(n)
+		}
+	}
+
+	// Parent or next sibling might be added later in the same mutation
+	pending := m.Adds
+	for len(pending) > 0 {
+		var rest []*addedNode
+		for _, a := range pending {
+			parent, ok := c.nodes[a.ParentID]
+			if !ok || a.Node == nil {
+				rest = append(rest, a)
+				continue
+			}
+			var next *node
+			if a.NextID != nil {
+				if next, ok = c.nodes[*a.NextID]; !ok {
+					rest = append(rest, a)
+					continue
+				}
+			}
+			c.insert(a.Node, parent, next)
+		}
+		if len(rest) == len(pending) {
+			break // Nodes with unknown parents are skipped as rrweb does
+		}
+		pending = rest
+	}
+
+	for _, t := range m.Texts {
+		n, ok := c.nodes[t.ID]
+		if !ok || !n.emitted || t.Value == nil {
+			continue
+		}
+		n.text = *t.Value
+		c.setText(n)
+	}
+	for _, a := range m.Attributes {
+		n, ok := c.nodes[a.ID]
+		if !ok || !n.emitted {
+			continue
+		}
+		for name, value := range a.Attributes {
+			c.setAttribute(n, name, value)
+		}
+	}
+}
+
+func coordinate(v float64) uint64 {
+	if v < 0 {
+		return 0
+	}
+	return uint64(v)
+}
+
+// label is a text of the node as tracker collects for clicks
+func label(n *node) string {
+	var b strings.Builder
+	var walk func(n *node)
+	walk = func(n *node) {
+		if b.Len() >= maxLabelLength {
+			return
+		}
+		if n.tag == "" && n.id >= 0 {
+			b.WriteString(n.text)
+			b.WriteString(" ")
+		}
+		for _, ch := range n.children {
+			walk(ch)
+		}
+	}
+	walk(n)
+	text := []rune(strings.Join(strings.Fields(b.String()), " "))
+	if len(text) > maxLabelLength {
+		text = text[:maxLabelLength]
+	}
+	return string(text)
+}
+
+func inputLabel(n *node) string {
+	for _, attr := range []string{"aria-label", "placeholder", "name", "id"} {
+		if value := n.attrs[attr]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
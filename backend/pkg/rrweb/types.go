@@ -0,0 +1,119 @@
+package rrweb
+
+import "encoding/json"
+
+// Event types
+const (
+	EventDomContentLoaded    = 0
+	EventLoad                = 1
+	EventFullSnapshot        = 2
+	EventIncrementalSnapshot = 3
+	EventMeta                = 4
+	EventCustom              = 5
+	EventPlugin              = 6
+)
+
+// Sources of incremental snapshots
+const (
+	SourceMutation         = 0
+	SourceMouseMove        = 1
+	SourceMouseInteraction = 2
+	SourceScroll           = 3
+	SourceViewportResize   = 4
+	SourceInput            = 5
+	SourceTouchMove        = 6
+	SourceDrag             = 12
+)
+
+// Types of serialized nodes
+const (
+	NodeDocument     = 0
+	NodeDocumentType = 1
+	NodeElement      = 2
+	NodeText         = 3
+	NodeCDATA        = 4
+	NodeComment      = 5
+)
+
+// Mouse interaction type of the click
+const mouseClick = 2
+
+// Event is a single record of rrweb recording
+type Event struct {
+	Type      int             `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	Timestamp int64           `json:"timestamp"`
+}
+
+type serializedNode struct {
+	Type        int                    `json:"type"`
+	ID          int64                  `json:"id"`
+	TagName     string                 `json:"tagName"`
+	Attributes  map[string]interface{} `json:"attributes"` // string, number, true or style diff in mutations
+	ChildNodes  []*serializedNode      `json:"childNodes"`
+	TextContent string                 `json:"textContent"`
+	IsSVG       bool                   `json:"isSVG"`
+	IsStyle     bool                   `json:"isStyle"`
+}
+
+type fullSnapshotData struct {
+	Node          *serializedNode `json:"node"`
+	InitialOffset struct {
+		Top  int64 `json:"top"`
+		Left int64 `json:"left"`
+	} `json:"initialOffset"`
+}
+
+type metaData struct {
+	Href   string `json:"href"`
+	Width  uint64 `json:"width"`
+	Height uint64 `json:"height"`
+}
+
+type customData struct {
+	Tag     string          `json:"tag"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// incrementalData contains fields of all sources, only ones of the Source are filled
+type incrementalData struct {
+	Source int `json:"source"`
+
+	// Mutation
+	Texts []struct {
+		ID    int64   `json:"id"`
+		Value *string `json:"value"`
+	} `json:"texts"`
+	Attributes []struct {
+		ID         int64                  `json:"id"`
+		Attributes map[string]interface{} `json:"attributes"`
+	} `json:"attributes"`
+	Removes []struct {
+		ParentID int64 `json:"parentId"`
+		ID       int64 `json:"id"`
+	} `json:"removes"`
+	Adds []*addedNode `json:"adds"`
+
+	// Mouse and touch moves
+	Positions []struct {
+		X          float64 `json:"x"`
+		Y          float64 `json:"y"`
+		TimeOffset int64   `json:"timeOffset"`
+	} `json:"positions"`
+
+	// Mouse interaction, scroll, viewport resize and input
+	Type      int     `json:"type"`
+	ID        int64   `json:"id"`
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Width     uint64  `json:"width"`
+	Height    uint64  `json:"height"`
+	Text      string  `json:"text"`
+	IsChecked bool    `json:"isChecked"`
+}
+
+type addedNode struct {
+	ParentID int64           `json:"parentId"`
+	NextID   *int64          `json:"nextId"`
+	Node     *serializedNode `json:"node"`
+}
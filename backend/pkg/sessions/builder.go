@@ -14,6 +14,7 @@ type builder struct {
 	timestamp      uint64
 	lastMessageID  uint64
 	lastSystemTime time.Time
+	clockSkew      int64 // server's time minus user's time
 	processors     []handlers.MessageProcessor
 	ended          bool
 }
@@ -47,7 +48,19 @@ func (b *builder) handleMessage(message Message, messageID uint64) {
 		log.Printf("skip message with wrong msgID, sessID: %d, msgID: %d, lastID: %d", b.sessionID, messageID, b.lastMessageID)
 		return
 	}
+	// Session-level messages from http don't have a timestamp and aren't handled by processors
+	switch m := message.(type) {
+	case *SessionClockSkew:
+		b.clockSkew = m.ClockSkew
+		return
+	case *SessionGeo:
+		return
+	}
 	timestamp := GetTimestamp(message)
+	if IsServerIndex(messageID) {
+		// Messages composed by the server have server's timestamps, don't mix them with user's ones
+		timestamp = ToUserTime(timestamp, b.clockSkew)
+	}
 	if timestamp == 0 {
 		switch message.(type) {
 		case *IssueEvent, *PerformanceTrackAggr:
@@ -30,6 +30,8 @@ func (m *builderMap) GetBuilder(sessionID uint64) *builder {
 	return b
 }
 
+// HandleMessage keeps msg in the session builder, so msg must not be a raw message of the iterator
+// (it's valid until the next Next) or a message released to the pool
 func (m *builderMap) HandleMessage(sessionID uint64, msg Message, messageID uint64) {
 	b := m.GetBuilder(sessionID)
 	b.handleMessage(msg, messageID)
@@ -61,6 +61,14 @@ func (s3 *S3) Get(key string) (io.ReadCloser, error) {
 	return out.Body, nil
 }
 
+// Ping checks that the bucket is accessible
+func (s3 *S3) Ping() error {
+	_, err := s3.svc.HeadBucket(&_s3.HeadBucketInput{
+		Bucket: s3.bucket,
+	})
+	return err
+}
+
 func (s3 *S3) Exists(key string) bool {
 	_, err := s3.svc.HeadObject(&_s3.HeadObjectInput{
 		Bucket: s3.bucket,
@@ -1,55 +1,137 @@
 package token
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"errors"
+	"fmt"
+	"log"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/btcsuite/btcutil/base58"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+
+	"openreplay/backend/pkg/monitoring"
 )
 
 var EXPIRED = errors.New("token expired")
 
+// Key ID of tokens composed before key rotation was added (without key ID part)
+const legacyKeyID = "legacy"
+
 type Tokenizer struct {
-	secret []byte
+	keyID         string            // Active signing key, empty if only legacy secret is configured
+	keys          map[string][]byte // Key ID -> secret
+	withProjectID bool              // Compose tokens with project ID
+	keyUses       syncfloat64.Counter
+}
+
+// NewTokenizer creates tokenizer with keyring in format "id1:secret1,id2:secret2".
+// The first key signs new tokens, the rest are used only for verification of issued tokens.
+// Legacy secret verifies tokens without key ID and signs new tokens if keyring is empty,
+// so "legacy" can't be used as an ID in the keyring.
+// Tokens with project ID are composed only if withProjectID is set, all the formats are parsed anyway.
+func NewTokenizer(secrets string, legacySecret string, withProjectID bool, metrics *monitoring.Metrics) *Tokenizer {
+	keyID, keys, err := parseKeyring(secrets)
+	if err != nil {
+		log.Fatalf("can't parse token secrets: %s", err)
+	}
+	if legacySecret != "" {
+		keys[legacyKeyID] = []byte(legacySecret)
+	}
+	if len(keys) == 0 {
+		log.Fatalf("token secret is not configured")
+	}
+	tokenizer := &Tokenizer{keyID: keyID, keys: keys, withProjectID: withProjectID}
+	if metrics != nil {
+		tokenizer.keyUses, err = metrics.RegisterCounter("token_key_uses")
+		if err != nil {
+			log.Printf("can't create token_key_uses metric: %s", err)
+		}
+	}
+	return tokenizer
 }
 
-func NewTokenizer(secret string) *Tokenizer {
-	return &Tokenizer{[]byte(secret)}
+// parseKeyring returns the keys of "id1:secret1,id2:secret2" and the ID of the first one
+func parseKeyring(secrets string) (string, map[string][]byte, error) {
+	keyID, keys := "", make(map[string][]byte)
+	for _, key := range strings.Split(secrets, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		parts := strings.SplitN(key, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" || strings.Contains(parts[0], ".") {
+			return "", nil, errors.New("wrong token secret format, expected id:secret")
+		}
+		if parts[0] == legacyKeyID {
+			return "", nil, fmt.Errorf("token key id %s is reserved for TOKEN_SECRET", legacyKeyID)
+		}
+		if _, ok := keys[parts[0]]; ok {
+			return "", nil, fmt.Errorf("duplicated token key id: %s", parts[0])
+		}
+		keys[parts[0]] = []byte(parts[1])
+		if keyID == "" {
+			keyID = parts[0]
+		}
+	}
+	return keyID, keys, nil
 }
 
 type TokenData struct {
-	ID      uint64
-	ExpTime int64
+	ID        uint64
+	ExpTime   int64
+	ProjectID uint32 // Empty for tokens composed without project ID
 }
 
-func (tokenizer *Tokenizer) sign(body string) []byte {
-	mac := hmac.New(sha256.New, tokenizer.secret)
+func (tokenizer *Tokenizer) sign(keyID string, body string) []byte {
+	mac := hmac.New(sha256.New, tokenizer.keys[keyID])
 	mac.Write([]byte(body))
 	return mac.Sum(nil)
 }
 
+// Compose returns the token in format "id.exp[.keyID].sign" or "id.exp.projectID.keyID.sign" with project ID.
+// Services which don't parse the latter respond 401 to it, so project ID is rolled out in two steps:
+// all the services which parse tokens (http and integrations) are upgraded first, then TOKEN_PROJECT_ID is enabled.
 func (tokenizer *Tokenizer) Compose(d TokenData) string {
-	body := strconv.FormatUint(d.ID, 36) +
-		"." + strconv.FormatInt(d.ExpTime, 36)
-	sign := base58.Encode(tokenizer.sign(body))
+	body := strconv.FormatUint(d.ID, 36) + "." + strconv.FormatInt(d.ExpTime, 36)
+	keyID := legacyKeyID
+	if tokenizer.keyID != "" {
+		keyID = tokenizer.keyID
+	}
+	if tokenizer.withProjectID {
+		// Key ID is always present, so the project ID can't be taken for it
+		body += "." + strconv.FormatUint(uint64(d.ProjectID), 36) + "." + keyID
+	} else if keyID != legacyKeyID {
+		body += "." + keyID
+	}
+	sign := base58.Encode(tokenizer.sign(keyID, body))
 	return body + "." + sign
 }
 
 func (tokenizer *Tokenizer) Parse(token string) (*TokenData, error) {
 	data := strings.Split(token, ".")
-	if len(data) != 3 {
+	if len(data) < 3 || len(data) > 5 {
 		return nil, errors.New("wrong token format")
 	}
+	keyID := legacyKeyID
+	if len(data) > 3 {
+		keyID = data[len(data)-2]
+	}
+	if _, ok := tokenizer.keys[keyID]; !ok {
+		return nil, errors.New("unknown token key")
+	}
 	if !hmac.Equal(
 		base58.Decode(data[len(data)-1]),
-		tokenizer.sign(data[0]+"."+data[1]),
+		tokenizer.sign(keyID, strings.Join(data[:len(data)-1], ".")),
 	) {
 		return nil, errors.New("wrong token sign")
 	}
+	tokenizer.countKeyUse(keyID)
 	id, err := strconv.ParseUint(data[0], 36, 64)
 	if err != nil {
 		return nil, err
@@ -58,8 +140,23 @@ func (tokenizer *Tokenizer) Parse(token string) (*TokenData, error) {
 	if err != nil {
 		return nil, err
 	}
+	tokenData := &TokenData{ID: id, ExpTime: expTime}
+	if len(data) == 5 {
+		projectID, err := strconv.ParseUint(data[2], 36, 32)
+		if err != nil {
+			return nil, err
+		}
+		tokenData.ProjectID = uint32(projectID)
+	}
 	if expTime <= time.Now().UnixMilli() {
-		return &TokenData{id, expTime}, EXPIRED
+		return tokenData, EXPIRED
+	}
+	return tokenData, nil
+}
+
+func (tokenizer *Tokenizer) countKeyUse(keyID string) {
+	if tokenizer.keyUses == nil {
+		return
 	}
-	return &TokenData{id, expTime}, nil
+	tokenizer.keyUses.Add(context.Background(), 1, attribute.String("key_id", keyID))
 }
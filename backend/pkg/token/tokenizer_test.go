@@ -0,0 +1,160 @@
+package token
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenFormat(t *testing.T) {
+	data := TokenData{ID: 7431236437891234, ExpTime: time.Now().Add(time.Hour).UnixMilli(), ProjectID: 42}
+	tests := []struct {
+		name          string
+		secrets       string
+		withProjectID bool
+		parts         int
+		keyID         string
+	}{
+		{"legacy", "", false, 3, ""},
+		{"keyring", "v2:new,v1:old", false, 4, "v2"},
+		{"legacy with project", "", true, 5, legacyKeyID},
+		{"keyring with project", "v2:new,v1:old", true, 5, "v2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenizer := NewTokenizer(tt.secrets, "legacy_secret", tt.withProjectID, nil)
+			token := tokenizer.Compose(data)
+			parts := strings.Split(token, ".")
+			if len(parts) != tt.parts {
+				t.Fatalf("token %s has %d parts, want %d", token, len(parts), tt.parts)
+			}
+			if tt.keyID != "" && parts[len(parts)-2] != tt.keyID {
+				t.Errorf("token %s has key ID %s, want %s", token, parts[len(parts)-2], tt.keyID)
+			}
+			parsed, err := tokenizer.Parse(token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := data
+			if !tt.withProjectID {
+				want.ProjectID = 0
+			}
+			if *parsed != want {
+				t.Errorf("got %+v, want %+v", *parsed, want)
+			}
+		})
+	}
+}
+
+// Tokens of every format are parsed regardless of the project ID setting
+func TestTokenFormatRollout(t *testing.T) {
+	data := TokenData{ID: 1, ExpTime: time.Now().Add(time.Hour).UnixMilli(), ProjectID: 42}
+	for _, secrets := range []string{"", "v1:secret"} {
+		before := NewTokenizer(secrets, "legacy_secret", false, nil)
+		after := NewTokenizer(secrets, "legacy_secret", true, nil)
+		if _, err := before.Parse(after.Compose(data)); err != nil {
+			t.Errorf("token with project ID isn't parsed, secrets %q: %s", secrets, err)
+		}
+		if _, err := after.Parse(before.Compose(data)); err != nil {
+			t.Errorf("token without project ID isn't parsed, secrets %q: %s", secrets, err)
+		}
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets string
+		keyID   string
+		keys    int
+		wantErr bool
+	}{
+		{"empty", "", "", 0, false},
+		{"single", "v1:secret", "v1", 1, false},
+		{"first key signs", " v2:new , v1:old ,", "v2", 2, false},
+		{"colon in secret", "v1:a:b", "v1", 1, false},
+		{"reserved legacy ID", "v2:new,legacy:old", "", 0, true},
+		{"duplicated ID", "v1:a,v1:b", "", 0, true},
+		{"without secret", "v1", "", 0, true},
+		{"empty secret", "v1:", "", 0, true},
+		{"empty ID", ":secret", "", 0, true},
+		{"dot in ID", "v.1:secret", "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyID, keys, err := parseKeyring(tt.secrets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if keyID != tt.keyID || len(keys) != tt.keys {
+				t.Errorf("got key %q of %d, want %q of %d", keyID, len(keys), tt.keyID, tt.keys)
+			}
+		})
+	}
+}
+
+func TestTokenKeyring(t *testing.T) {
+	data := TokenData{ID: 1, ExpTime: time.Now().Add(time.Hour).UnixMilli()}
+	legacy := NewTokenizer("", "legacy_secret", false, nil)
+	v1 := NewTokenizer("v1:old", "legacy_secret", false, nil)
+	tests := []struct {
+		name    string
+		token   string
+		secrets string
+		legacy  string
+		wantErr bool
+	}{
+		{"rotated key", v1.Compose(data), "v2:new,v1:old", "", false},
+		{"removed key", v1.Compose(data), "v2:new", "legacy_secret", true},
+		{"legacy token", legacy.Compose(data), "v2:new", "legacy_secret", false},
+		{"legacy token without legacy secret", legacy.Compose(data), "v2:new", "", true},
+		{"same ID with other secret", v1.Compose(data), "v1:other", "", true},
+		{"legacy ID in token", strings.Replace(v1.Compose(data), ".v1.", ".legacy.", 1), "", "old", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTokenizer(tt.secrets, tt.legacy, false, nil).Parse(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTokenParseErrors(t *testing.T) {
+	tokenizer := NewTokenizer("v1:secret", "", true, nil)
+	token := tokenizer.Compose(TokenData{ID: 1, ExpTime: time.Now().Add(time.Hour).UnixMilli(), ProjectID: 42})
+	parts := strings.Split(token, ".")
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"too short", "1.2"},
+		{"too long", token + ".x"},
+		{"other session", "2." + strings.Join(parts[1:], ".")},
+		{"other project", strings.Join(append(append([]string{}, parts[:2]...), "a", parts[3], parts[4]), ".")},
+		{"broken sign", token[:len(token)-2]},
+		{"unknown key", strings.Replace(token, ".v1.", ".v2.", 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tokenizer.Parse(tt.token); err == nil || err == EXPIRED {
+				t.Errorf("token %q is accepted", tt.token)
+			}
+		})
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	tokenizer := NewTokenizer("v1:secret", "", false, nil)
+	data := TokenData{ID: 1, ExpTime: time.Now().Add(-time.Second).UnixMilli()}
+	parsed, err := tokenizer.Parse(tokenizer.Compose(data))
+	if err != EXPIRED {
+		t.Fatalf("got error %v, want EXPIRED", err)
+	}
+	// Session ID of the expired token is still returned
+	if parsed == nil || parsed.ID != data.ID {
+		t.Errorf("got %+v, want session ID %d", parsed, data.ID)
+	}
+}
@@ -283,6 +283,44 @@ def get_project_key(project_id):
         return project["project_key"] if project is not None else None
 
 
+def get_secret_key(tenant_id, user_id, project_id):
+    admin = users.get(user_id=user_id, tenant_id=tenant_id)
+    if not admin["admin"] and not admin["superAdmin"]:
+        return {"errors": ["unauthorized"]}
+    with pg_client.PostgresClient() as cur:
+        cur.execute(
+            cur.mogrify("""\
+                    SELECT secret_key
+                    FROM public.projects 
+                    WHERE project_id =%(project_id)s AND tenant_id = %(tenant_id)s AND deleted_at ISNULL;""",
+                        {"project_id": project_id, "tenant_id": tenant_id})
+        )
+        row = cur.fetchone()
+    if row is None:
+        return {"errors": ["project not found"]}
+    return {"data": {"secretKey": row["secret_key"]}}
+
+
+# the old key can still be accepted by the ingest service until its project cache expires
+def rotate_secret_key(tenant_id, user_id, project_id):
+    admin = users.get(user_id=user_id, tenant_id=tenant_id)
+    if not admin["admin"] and not admin["superAdmin"]:
+        return {"errors": ["unauthorized"]}
+    with pg_client.PostgresClient() as cur:
+        cur.execute(
+            cur.mogrify("""\
+                    UPDATE public.projects
+                    SET secret_key = generate_api_key(40)
+                    WHERE project_id =%(project_id)s AND tenant_id = %(tenant_id)s AND deleted_at ISNULL
+                    RETURNING secret_key;""",
+                        {"project_id": project_id, "tenant_id": tenant_id})
+        )
+        row = cur.fetchone()
+    if row is None:
+        return {"errors": ["project not found"]}
+    return {"data": {"secretKey": row["secret_key"]}}
+
+
 def get_capture_status(project_id):
     with pg_client.PostgresClient() as cur:
         cur.execute(
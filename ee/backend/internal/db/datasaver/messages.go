@@ -6,6 +6,7 @@ import (
 	"openreplay/backend/pkg/messages"
 )
 
+// InsertMessage may keep msg in the batches, so it must be a decoded message which isn't released to the pool
 func (mi *Saver) InsertMessage(sessionID uint64, msg messages.Message) error {
 	switch m := msg.(type) {
 	// Common
@@ -16,6 +17,12 @@ func (mi *Saver) InsertMessage(sessionID uint64, msg messages.Message) error {
 		return nil
 	case *messages.IssueEvent:
 		return mi.pg.InsertIssueEvent(sessionID, m)
+	case *messages.SessionAssociation:
+		return mi.pg.InsertSessionAssociation(sessionID, m.PreviousSessionID)
+	case *messages.SessionGeo:
+		return mi.pg.InsertSessionGeo(sessionID, m)
+	case *messages.SessionClockSkew:
+		return mi.pg.InsertSessionClockSkew(sessionID, m)
 	//TODO: message adapter (transformer) (at the level of pkg/message) for types: *IOSMetadata, *IOSIssueEvent and others
 
 	// Web
@@ -73,9 +80,14 @@ func (mi *Saver) InsertMessage(sessionID uint64, msg messages.Message) error {
 		}
 		return mi.pg.InsertWebGraphQLEvent(sessionID, m)
 	case *messages.IntegrationEvent:
+		// Integration events come with backend timestamps, put them on the user's timeline
+		var clockSkew int64
+		if session, err := mi.pg.GetSession(sessionID); err == nil && session != nil {
+			clockSkew = session.ClockSkew
+		}
 		return mi.pg.InsertWebErrorEvent(sessionID, &messages.ErrorEvent{
 			MessageID: m.Meta().Index,
-			Timestamp: m.Timestamp,
+			Timestamp: messages.ToUserTime(m.Timestamp, clockSkew),
 			Source:    m.Source,
 			Name:      m.Name,
 			Message:   m.Message,
@@ -84,6 +96,10 @@ func (mi *Saver) InsertMessage(sessionID uint64, msg messages.Message) error {
 	case *messages.SetPageLocation:
 		return mi.pg.InsertSessionReferrer(sessionID, m.Referrer)
 
+		// Android
+	case *messages.AndroidSessionStart:
+		return mi.pg.InsertAndroidSessionStart(sessionID, m)
+
 		// IOS
 	case *messages.IOSSessionStart:
 		return mi.pg.InsertIOSSessionStart(sessionID, m)
@@ -5,6 +5,7 @@ import (
 	"openreplay/backend/pkg/db/clickhouse"
 	"openreplay/backend/pkg/db/types"
 	"openreplay/backend/pkg/env"
+	"openreplay/backend/pkg/health"
 	"openreplay/backend/pkg/messages"
 )
 
@@ -16,6 +17,10 @@ func (si *Saver) InitStats() {
 	si.pg.Conn.SetClickHouse(si.ch)
 }
 
+func (si *Saver) AddHealthChecks(h *health.Health) {
+	h.AddCheck("clickhouse", si.ch.Ping)
+}
+
 func (si *Saver) InsertStats(session *types.Session, msg messages.Message) error {
 	switch m := msg.(type) {
 	// Web
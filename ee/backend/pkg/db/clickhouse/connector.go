@@ -69,6 +69,7 @@ var CONTAINER_TYPE_MAP = map[uint64]string{0: "window", 1: "iframe", 2: "embed",
 type Connector interface {
 	Prepare() error
 	Commit() error
+	Ping() error
 	InsertWebSession(session *types.Session) error
 	InsertWebResourceEvent(session *types.Session, msg *messages.ResourceEvent) error
 	InsertWebPageEvent(session *types.Session, msg *messages.PageEvent) error
@@ -125,7 +126,7 @@ func (c *connectorImpl) newBatch(name, query string) error {
 }
 
 var batches = map[string]string{
-	"sessions":      "INSERT INTO experimental.sessions (session_id, project_id, user_id, user_uuid, user_os, user_os_version, user_device, user_device_type, user_country, datetime, duration, pages_count, events_count, errors_count, issue_score, referrer, issue_types, tracker_version, user_browser, user_browser_version, metadata_1, metadata_2, metadata_3, metadata_4, metadata_5, metadata_6, metadata_7, metadata_8, metadata_9, metadata_10) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+	"sessions":      "INSERT INTO experimental.sessions (session_id, project_id, user_id, user_uuid, user_os, user_os_version, user_device, user_device_type, user_country, user_region, user_city, user_latitude, user_longitude, user_timezone, user_asn, user_asn_org, datetime, duration, pages_count, events_count, errors_count, issue_score, referrer, issue_types, tracker_version, user_browser, user_browser_version, metadata_1, metadata_2, metadata_3, metadata_4, metadata_5, metadata_6, metadata_7, metadata_8, metadata_9, metadata_10) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 	"resources":     "INSERT INTO experimental.resources (session_id, project_id, message_id, datetime, url, type, duration, ttfb, header_size, encoded_body_size, decoded_body_size, success) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 	"autocompletes": "INSERT INTO experimental.autocomplete (project_id, type, value) VALUES (?, ?, ?)",
 	"pages":         "INSERT INTO experimental.events (session_id, project_id, message_id, datetime, url, request_start, response_start, response_end, dom_content_loaded_event_start, dom_content_loaded_event_end, load_event_start, load_event_end, first_paint, first_contentful_paint_time, speed_index, visually_complete, time_to_interactive, event_type) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
@@ -147,6 +148,12 @@ func (c *connectorImpl) Prepare() error {
 	return nil
 }
 
+func (c *connectorImpl) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return c.conn.Ping(ctx)
+}
+
 func (c *connectorImpl) Commit() error {
 	for _, b := range c.batches {
 		if err := b.Send(); err != nil {
@@ -176,6 +183,13 @@ func (c *connectorImpl) InsertWebSession(session *types.Session) error {
 		nullableString(session.UserDevice),
 		session.UserDeviceType,
 		session.UserCountry,
+		nullableString(session.UserRegion),
+		nullableString(session.UserCity),
+		nullableFloat32(session.UserLatitude),
+		nullableFloat32(session.UserLongitude),
+		nullableString(session.UserTimezone),
+		nullableUint32(uint32(session.UserASN)),
+		nullableString(session.UserASNOrg),
 		datetime(session.Timestamp),
 		uint32(*session.Duration),
 		uint16(session.PagesCount),
@@ -445,6 +459,14 @@ func nullableString(v string) *string {
 	return p
 }
 
+func nullableFloat32(v *float64) *float32 {
+	if v == nil {
+		return nil
+	}
+	p := float32(*v)
+	return &p
+}
+
 func datetime(timestamp uint64) time.Time {
 	t := time.Unix(int64(timestamp/1e3), 0)
 	// Temporal solution for not correct timestamps in performance messages
@@ -6,6 +6,7 @@ import (
 	config "openreplay/backend/internal/config/storage"
 	"openreplay/backend/internal/storage"
 	"openreplay/backend/pkg/messages"
+	"openreplay/backend/pkg/monitoring"
 	"openreplay/backend/pkg/queue"
 	"openreplay/backend/pkg/queue/types"
 	"strconv"
@@ -35,7 +36,7 @@ type sessionFinderImpl struct {
 	done             chan struct{}
 }
 
-func NewSessionFinder(cfg *config.Config, stg *storage.Storage) (SessionFinder, error) {
+func NewSessionFinder(cfg *config.Config, stg *storage.Storage, metrics *monitoring.Metrics) (SessionFinder, error) {
 	switch {
 	case cfg == nil:
 		return nil, fmt.Errorf("config is empty")
@@ -70,6 +71,7 @@ func NewSessionFinder(cfg *config.Config, stg *storage.Storage) (SessionFinder,
 		},
 		true,
 		cfg.MessageSizeLimit,
+		metrics,
 	)
 	go finder.worker()
 	return finder, nil
@@ -7,13 +7,31 @@ import (
 
 	"gopkg.in/confluentinc/confluent-kafka-go.v1/kafka"
 	"openreplay/backend/pkg/env"
+	"openreplay/backend/pkg/queue/types"
 )
 
 type Producer struct {
-	producer *kafka.Producer
+	producer    *kafka.Producer
+	onFailed    types.DeliveryFailureHandler
+	handlerDone chan struct{}
 }
 
 func NewProducer(messageSizeLimit int, useBatch bool) *Producer {
+	return newProducer(newProducerConfig(messageSizeLimit, useBatch), nil)
+}
+
+// NewReportingProducer returns a producer which never drops accepted messages: they are retried until delivered.
+// Produce returns an error if the local queue is full, messages left in the queue on Close are passed to onFailed.
+// Messages which were sent to the broker might be delivered, so they aren't reported.
+func NewReportingProducer(messageSizeLimit int, onFailed types.DeliveryFailureHandler) *Producer {
+	kafkaConfig := newProducerConfig(messageSizeLimit, false)
+	kafkaConfig.SetKey("message.timeout.ms", 0) // infinite
+	// Messages over this size wait in the memory of the caller instead (e.g. the spool of http)
+	kafkaConfig.SetKey("queue.buffering.max.kbytes", 131072)
+	return newProducer(kafkaConfig, onFailed)
+}
+
+func newProducerConfig(messageSizeLimit int, useBatch bool) *kafka.ConfigMap {
 	kafkaConfig := &kafka.ConfigMap{
 		"enable.idempotence":     true,
 		"bootstrap.servers":      env.String("KAFKA_SERVERS"),
@@ -30,47 +48,73 @@ func NewProducer(messageSizeLimit int, useBatch bool) *Producer {
 		kafkaConfig.SetKey("ssl.key.location", os.Getenv("KAFKA_SSL_KEY"))
 		kafkaConfig.SetKey("ssl.certificate.location", os.Getenv("KAFKA_SSL_CERT"))
 	}
+	return kafkaConfig
+}
+
+func newProducer(kafkaConfig *kafka.ConfigMap, onFailed types.DeliveryFailureHandler) *Producer {
 	producer, err := kafka.NewProducer(kafkaConfig)
 	if err != nil {
 		log.Fatalln(err)
 	}
-	newProducer := &Producer{producer}
+	newProducer := &Producer{producer: producer, onFailed: onFailed, handlerDone: make(chan struct{})}
 	go newProducer.errorHandler()
 	return newProducer
 }
 
 func (p *Producer) errorHandler() {
+	defer close(p.handlerDone)
 	for e := range p.producer.Events() {
 		switch ev := e.(type) {
 		case *kafka.Message:
-			if ev.TopicPartition.Error != nil {
-				fmt.Printf("Delivery failed: topicPartition: %v, key: %d\n", ev.TopicPartition, decodeKey(ev.Key))
+			if ev.TopicPartition.Error == nil {
+				continue
 			}
+			// Purged from the local queue, so it was never sent to the broker
+			if err, ok := ev.TopicPartition.Error.(kafka.Error); ok && err.Code() == kafka.ErrPurgeQueue && p.onFailed != nil {
+				p.onFailed(*ev.TopicPartition.Topic, decodeKey(ev.Key), ev.Value)
+				continue
+			}
+			fmt.Printf("Delivery failed: topicPartition: %v, key: %d\n", ev.TopicPartition, decodeKey(ev.Key))
 		}
 	}
 }
 
 func (p *Producer) Produce(topic string, key uint64, value []byte) error {
-	p.producer.ProduceChannel() <- &kafka.Message{
+	return p.produce(&kafka.Message{
 		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: getKeyPartition(key)},
 		Key:            encodeKey(key),
 		Value:          value,
-	}
-	return nil
+	})
 }
 
 func (p *Producer) ProduceToPartition(topic string, partition, key uint64, value []byte) error {
-	p.producer.ProduceChannel() <- &kafka.Message{
+	return p.produce(&kafka.Message{
 		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: int32(partition)},
 		Key:            encodeKey(key),
 		Value:          value,
+	})
+}
+
+func (p *Producer) produce(msg *kafka.Message) error {
+	if p.onFailed == nil {
+		p.producer.ProduceChannel() <- msg
+		return nil
 	}
-	return nil
+	// Returns the error if the local queue is full, delivery report goes to the events channel
+	return p.producer.Produce(msg, nil)
 }
 
 func (p *Producer) Close(timeoutMs int) {
 	p.producer.Flush(timeoutMs)
+	if p.onFailed != nil {
+		// Reports of the purged messages are handled before the events channel is closed
+		if err := p.producer.Purge(kafka.PurgeQueue); err != nil {
+			log.Printf("can't purge producer queue: %s", err)
+		}
+		p.producer.Flush(timeoutMs)
+	}
 	p.producer.Close()
+	<-p.handlerDone
 }
 
 func (p *Producer) Flush(timeoutMs int) {
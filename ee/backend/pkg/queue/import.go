@@ -15,3 +15,8 @@ func NewProducer(messageSizeLimit int, useBatch bool) types.Producer {
 	license.CheckLicense()
 	return kafka.NewProducer(messageSizeLimit, useBatch)
 }
+
+func NewReportingProducer(messageSizeLimit int, onFailed types.DeliveryFailureHandler) types.Producer {
+	license.CheckLicense()
+	return kafka.NewReportingProducer(messageSizeLimit, onFailed)
+}
@@ -63,6 +63,26 @@ class SessionStart(Message):
         self.user_id = user_id
 
 
+class SessionGeo(Message):
+    __id__ = 83
+
+    def __init__(self, user_region, user_city, user_latitude, user_longitude, user_timezone, user_asn, user_asn_org):
+        self.user_region = user_region
+        self.user_city = user_city
+        self.user_latitude = user_latitude
+        self.user_longitude = user_longitude
+        self.user_timezone = user_timezone
+        self.user_asn = user_asn
+        self.user_asn_org = user_asn_org
+
+
+class SessionClockSkew(Message):
+    __id__ = 84
+
+    def __init__(self, clock_skew):
+        self.clock_skew = clock_skew
+
+
 class SessionEnd(Message):
     __id__ = 3
 
@@ -589,6 +609,15 @@ class PerformanceTrackAggr(Message):
         self.max_used_js_heap_size = max_used_js_heap_size
 
 
+class SessionAssociation(Message):
+    __id__ = 57
+
+    def __init__(self, session_id, previous_session_id, timestamp):
+        self.session_id = session_id
+        self.previous_session_id = previous_session_id
+        self.timestamp = timestamp
+
+
 class LongTask(Message):
     __id__ = 59
 
@@ -777,6 +806,22 @@ class IOSSessionStart(Message):
         self.user_country = user_country
 
 
+class AndroidSessionStart(Message):
+    __id__ = 112
+
+    def __init__(self, timestamp, project_id, tracker_version, rev_id, user_uuid, user_os, user_os_version, user_device, user_device_type, user_country):
+        self.timestamp = timestamp
+        self.project_id = project_id
+        self.tracker_version = tracker_version
+        self.rev_id = rev_id
+        self.user_uuid = user_uuid
+        self.user_os = user_os
+        self.user_os_version = user_os_version
+        self.user_device = user_device
+        self.user_device_type = user_device_type
+        self.user_country = user_country
+
+
 class IOSSessionEnd(Message):
     __id__ = 91
 
@@ -123,6 +123,22 @@ class MessageCodec(Codec):
                 user_id=self.read_string(reader)
             )
 
+        if message_id == 83:
+            return SessionGeo(
+                user_region=self.read_string(reader),
+                user_city=self.read_string(reader),
+                user_latitude=self.read_int(reader),
+                user_longitude=self.read_int(reader),
+                user_timezone=self.read_string(reader),
+                user_asn=self.read_uint(reader),
+                user_asn_org=self.read_string(reader)
+            )
+
+        if message_id == 84:
+            return SessionClockSkew(
+                clock_skew=self.read_int(reader)
+            )
+
         if message_id == 3:
             return SessionEnd(
                 timestamp=self.read_uint(reader)
@@ -541,6 +557,13 @@ class MessageCodec(Codec):
                 max_used_js_heap_size=self.read_uint(reader)
             )
 
+        if message_id == 57:
+            return SessionAssociation(
+                session_id=self.read_uint(reader),
+                previous_session_id=self.read_uint(reader),
+                timestamp=self.read_uint(reader)
+            )
+
         if message_id == 59:
             return LongTask(
                 timestamp=self.read_uint(reader),
@@ -689,6 +712,20 @@ class MessageCodec(Codec):
                 user_country=self.read_string(reader)
             )
 
+        if message_id == 112:
+            return AndroidSessionStart(
+                timestamp=self.read_uint(reader),
+                project_id=self.read_uint(reader),
+                tracker_version=self.read_string(reader),
+                rev_id=self.read_string(reader),
+                user_uuid=self.read_string(reader),
+                user_os=self.read_string(reader),
+                user_os_version=self.read_string(reader),
+                user_device=self.read_string(reader),
+                user_device_type=self.read_string(reader),
+                user_country=self.read_string(reader)
+            )
+
         if message_id == 91:
             return IOSSessionEnd(
                 timestamp=self.read_uint(reader)
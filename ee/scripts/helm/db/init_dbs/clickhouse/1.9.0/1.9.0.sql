@@ -0,0 +1,8 @@
+ALTER TABLE experimental.sessions
+    ADD COLUMN IF NOT EXISTS user_region Nullable(String) AFTER user_country,
+    ADD COLUMN IF NOT EXISTS user_city Nullable(String) AFTER user_region,
+    ADD COLUMN IF NOT EXISTS user_latitude Nullable(Float32) AFTER user_city,
+    ADD COLUMN IF NOT EXISTS user_longitude Nullable(Float32) AFTER user_latitude,
+    ADD COLUMN IF NOT EXISTS user_timezone LowCardinality(Nullable(String)) AFTER user_longitude,
+    ADD COLUMN IF NOT EXISTS user_asn Nullable(UInt32) AFTER user_timezone,
+    ADD COLUMN IF NOT EXISTS user_asn_org Nullable(String) AFTER user_asn;
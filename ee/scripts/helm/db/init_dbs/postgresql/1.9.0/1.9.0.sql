@@ -0,0 +1,51 @@
+BEGIN;
+CREATE OR REPLACE FUNCTION openreplay_version()
+    RETURNS text AS
+$$
+SELECT 'v1.9.0-ee'
+$$ LANGUAGE sql IMMUTABLE;
+
+ALTER TABLE IF EXISTS projects
+    ADD COLUMN IF NOT EXISTS sample_rules jsonb NULL DEFAULT NULL;
+
+ALTER TABLE IF EXISTS projects
+    ADD COLUMN IF NOT EXISTS allowed_origins text[] NULL DEFAULT NULL;
+
+ALTER TABLE IF EXISTS projects
+    ADD COLUMN IF NOT EXISTS monthly_sessions_quota bigint NULL DEFAULT NULL;
+
+ALTER TABLE IF EXISTS projects
+    ADD COLUMN IF NOT EXISTS scrub_rules jsonb NULL DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS sessions_associations
+(
+    session_id          bigint NOT NULL PRIMARY KEY,
+    previous_session_id bigint NOT NULL
+);
+CREATE INDEX IF NOT EXISTS sessions_associations_previous_session_id_idx ON sessions_associations (previous_session_id);
+
+ALTER TABLE IF EXISTS sessions
+    ADD COLUMN IF NOT EXISTS user_region    text   NULL DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS user_city      text   NULL DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS user_latitude  real   NULL DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS user_longitude real   NULL DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS user_timezone  text   NULL DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS user_asn       bigint NULL DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS user_asn_org   text   NULL DEFAULT NULL;
+CREATE INDEX IF NOT EXISTS sessions_project_id_user_region_idx ON sessions (project_id, user_region);
+CREATE INDEX IF NOT EXISTS sessions_project_id_user_city_idx ON sessions (project_id, user_city);
+CREATE INDEX IF NOT EXISTS sessions_project_id_user_asn_idx ON sessions (project_id, user_asn);
+
+-- Secret key authenticates server-to-server requests, it must never be exposed to the browser
+ALTER TABLE IF EXISTS projects
+    ADD COLUMN IF NOT EXISTS secret_key varchar(40) NOT NULL UNIQUE DEFAULT generate_api_key(40);
+
+-- Difference between server and user's clocks (ms) measured at the session start
+ALTER TABLE IF EXISTS sessions
+    ADD COLUMN IF NOT EXISTS clock_skew integer NULL DEFAULT NULL;
+
+-- Overrides of tracker options, sent to the tracker at the session start
+ALTER TABLE IF EXISTS projects
+    ADD COLUMN IF NOT EXISTS capture_settings jsonb NULL DEFAULT NULL;
+
+COMMIT;
@@ -24,6 +24,20 @@ message 90, 'IOSSessionStart',  :replayer => true  do
   string 'UserCountry'
 end
 
+# Android SDK sends the same mobile messages as iOS one, only the session start is different
+message 112, 'AndroidSessionStart', :replayer => false do
+  uint 'Timestamp'
+  uint 'ProjectID'
+  string 'TrackerVersion'
+  string 'RevID'
+  string 'UserUUID'
+  string 'UserOS'
+  string 'UserOSVersion'
+  string 'UserDevice'
+  string 'UserDeviceType'
+  string 'UserCountry'
+end
+
 message 91, 'IOSSessionEnd'  do 
 	uint 'Timestamp'
 end
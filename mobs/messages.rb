@@ -17,6 +17,7 @@ message 81, 'BatchMetadata', :replayer => false do
 end
 
 # since tracker 3.6.0
+# Backend services also put it before every chunk of the batch which is over the queue message size limit
 message 82, 'PartitionedMessage', :replayer => false do
   uint 'PartNo'
   uint 'PartTotal'
@@ -44,6 +45,21 @@ message 1, 'SessionStart', :tracker => false, :replayer => false do
   string 'UserCountry'
   string 'UserID'
 end
+# Sent by http right after SessionStart/IOSSessionStart/AndroidSessionStart in its own batch,
+# so that consumers which do not know it only lose that batch.
+message 83, 'SessionGeo', :tracker => false, :replayer => false do
+  string 'UserRegion'
+  string 'UserCity'
+  int 'UserLatitude' # rounded to 0.1 degree and multiplied by 10
+  int 'UserLongitude'
+  string 'UserTimezone'
+  uint 'UserASN'
+  string 'UserASNOrg'
+end
+# Sent by http right after SessionStart in its own batch, same as SessionGeo
+message 84, 'SessionClockSkew', :tracker => false, :replayer => false do
+  int 'ClockSkew' # server's time minus user's time at the session start, ms
+end
 ## message 2, 'CreateDocument', do
 # end
 message 3, 'SessionEnd', :tracker => false, :replayer => false do
@@ -69,7 +85,7 @@ end
 message 8, 'CreateElementNode' do
   uint 'ID'
   uint 'ParentID'
-  uint 'index'
+  uint 'Index'
   string 'Tag'
   boolean 'SVG'
 end
@@ -343,7 +359,7 @@ message 54, 'ConnectionInformation' do
   string 'Type'
 end
 message 55, 'SetPageVisibility' do
-  boolean 'hidden'
+  boolean 'Hidden'
 end
 message 56, 'PerformanceTrackAggr', :tracker => false, :replayer => false do
   uint 'TimestampStart'
@@ -361,7 +377,13 @@ message 56, 'PerformanceTrackAggr', :tracker => false, :replayer => false do
   uint 'AvgUsedJSHeapSize'
   uint 'MaxUsedJSHeapSize'
 end
-## 57 58
+# Sent by backend when the tracker continues the visit with an expired token
+message 57, 'SessionAssociation', :tracker => false, :replayer => false do
+  uint 'SessionID'
+  uint 'PreviousSessionID'
+  uint 'Timestamp'
+end
+## 58
 message 59, 'LongTask' do
   uint 'Timestamp'
   uint 'Duration'
@@ -6,7 +6,7 @@ CREATE SCHEMA IF NOT EXISTS events;
 CREATE OR REPLACE FUNCTION openreplay_version()
     RETURNS text AS
 $$
-SELECT 'v1.8.1'
+SELECT 'v1.9.0'
 $$ LANGUAGE sql IMMUTABLE;
 
 -- --- accounts.sql ---
@@ -199,7 +199,13 @@ $$
                   "defaultInputMode": "plain"
                 }'::jsonb,
                 first_recorded_session_at timestamp without time zone NULL            DEFAULT NULL,
-                sessions_last_check_at    timestamp without time zone NULL            DEFAULT NULL
+                sessions_last_check_at    timestamp without time zone NULL            DEFAULT NULL,
+                sample_rules              jsonb                       NULL            DEFAULT NULL,
+                allowed_origins           text[]                      NULL            DEFAULT NULL,
+                monthly_sessions_quota    bigint                      NULL            DEFAULT NULL,
+                scrub_rules               jsonb                       NULL            DEFAULT NULL,
+                secret_key                varchar(40)                 NOT NULL UNIQUE DEFAULT generate_api_key(40),
+                capture_settings          jsonb                       NULL            DEFAULT NULL
             );
 
             CREATE INDEX projects_project_key_idx ON public.projects (project_key);
@@ -432,6 +438,14 @@ $$
                 user_device_memory_size integer               DEFAULT NULL,
                 user_device_heap_size   bigint                DEFAULT NULL,
                 user_country            country      NOT NULL,
+                user_region             text         NULL     DEFAULT NULL,
+                user_city               text         NULL     DEFAULT NULL,
+                user_latitude           real         NULL     DEFAULT NULL,
+                user_longitude          real         NULL     DEFAULT NULL,
+                user_timezone           text         NULL     DEFAULT NULL,
+                user_asn                bigint       NULL     DEFAULT NULL,
+                user_asn_org            text         NULL     DEFAULT NULL,
+                clock_skew              integer      NULL     DEFAULT NULL,
                 pages_count             integer      NOT NULL DEFAULT 0,
                 events_count            integer      NOT NULL DEFAULT 0,
                 errors_count            integer      NOT NULL DEFAULT 0,
@@ -459,6 +473,9 @@ $$
             CREATE INDEX sessions_project_id_user_anonymous_id_idx ON sessions (project_id, user_anonymous_id);
             CREATE INDEX sessions_project_id_user_device_idx ON sessions (project_id, user_device);
             CREATE INDEX sessions_project_id_user_country_idx ON sessions (project_id, user_country);
+            CREATE INDEX sessions_project_id_user_region_idx ON sessions (project_id, user_region);
+            CREATE INDEX sessions_project_id_user_city_idx ON sessions (project_id, user_city);
+            CREATE INDEX sessions_project_id_user_asn_idx ON sessions (project_id, user_asn);
             CREATE INDEX sessions_project_id_user_browser_idx ON sessions (project_id, user_browser);
             CREATE INDEX sessions_project_id_metadata_1_idx ON sessions (project_id, metadata_1);
             CREATE INDEX sessions_project_id_metadata_2_idx ON sessions (project_id, metadata_2);
@@ -530,6 +547,13 @@ $$
             );
             CREATE INDEX user_favorite_sessions_user_id_session_id_idx ON user_favorite_sessions (user_id, session_id);
 
+            CREATE TABLE sessions_associations
+            (
+                session_id          bigint NOT NULL PRIMARY KEY,
+                previous_session_id bigint NOT NULL
+            );
+            CREATE INDEX sessions_associations_previous_session_id_idx ON sessions_associations (previous_session_id);
+
 -- --- assignments.sql ---
 
             create table assigned_sessions
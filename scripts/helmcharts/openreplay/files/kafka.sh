@@ -8,6 +8,7 @@ RETENTION_TIME=${RETENTION_TIME:-345600000}
 topics=(
   "raw"
   "raw-ios"
+  "raw-android"
   "trigger"
   "cache"
   "analytics"